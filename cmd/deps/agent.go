@@ -29,22 +29,60 @@ func BuildAgent(c *config.AgentConf) (*agent.StatefulAgent, error) {
 }
 
 func BuildChecker(c *config.AgentConf) (checkers.Checker, error) {
-	switch c.CheckerName {
+	return buildChecker(c.CheckerName, c.CheckerArgs)
+}
+
+func buildChecker(name string, args map[string]any) (checkers.Checker, error) {
+	switch name {
 	case checkers.NeedrestartCheckerName:
-		return checkers.NeedrestartCheckerFromMap(c.CheckerArgs)
+		return checkers.NeedrestartCheckerFromMap(args)
+	case checkers.NeedsRestartingCheckerName:
+		return checkers.NeedsRestartingCheckerFromMap(args)
+	case checkers.KernelCheckerName:
+		return checkers.KernelCheckerFromMap(args)
 	case checkers.FileCheckerName:
-		return checkers.FileCheckerFromMap(c.CheckerArgs)
+		return checkers.FileCheckerFromMap(args)
 	case checkers.DnsCheckerName:
-		return checkers.DnsCheckerFromMap(c.CheckerArgs)
+		return checkers.DnsCheckerFromMap(args)
 	case checkers.PrometheusName:
-		return checkers.PrometheusCheckerFromMap(c.CheckerArgs)
+		return checkers.PrometheusCheckerFromMap(args)
 	case checkers.TcpName:
-		return checkers.TcpCheckerFromMap(c.CheckerArgs)
+		return checkers.TcpCheckerFromMap(args)
 	case checkers.IcmpCheckerName:
-		return checkers.IcmpCheckerFromMap(c.CheckerArgs)
+		return checkers.IcmpCheckerFromMap(args)
+	case checkers.KafkaCheckerName:
+		return checkers.KafkaCheckerFromMap(args)
+	case checkers.RebootRequiredCheckerName:
+		return checkers.RebootRequiredCheckerFromMap(args)
+	case checkers.ExecCheckerName:
+		return checkers.ExecCheckerFromMap(args)
+	case checkers.KmsgCheckerName:
+		return checkers.KmsgCheckerFromMap(args)
+	case checkers.NetworkInterfaceCheckerName:
+		return checkers.NetworkInterfaceCheckerFromMap(args)
+	case checkers.GatewayCheckerName:
+		return checkers.GatewayCheckerFromMap(args)
+	case checkers.HttpCheckerName:
+		return checkers.HttpCheckerFromMap(args)
+	case checkers.FilesystemCheckerName:
+		return checkers.FilesystemCheckerFromMap(args)
+	case checkers.UptimeCheckerName:
+		return checkers.UptimeCheckerFromMap(args)
+	case checkers.PsiCheckerName:
+		return checkers.PsiCheckerFromMap(args)
+	case checkers.NtpCheckerName:
+		return checkers.NtpCheckerFromMap(args)
+	case checkers.AlertmanagerCheckerName:
+		return checkers.AlertmanagerCheckerFromMap(args)
+	case checkers.SystemdCheckerName:
+		return checkers.SystemdCheckerFromMap(args)
+	case checkers.DeviceCheckerName:
+		return checkers.DeviceCheckerFromMap(args)
+	case checkers.QuorumCheckerName:
+		return checkers.QuorumCheckerFromMap(args, buildChecker)
 	}
 
-	return nil, fmt.Errorf("unknown checker: %s", c.CheckerName)
+	return nil, fmt.Errorf("unknown checker: %s", name)
 }
 
 func BuildPrecondition(c *config.AgentConf) (preconditions.Precondition, error) {
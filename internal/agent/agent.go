@@ -156,6 +156,15 @@ func (a *StatefulAgent) CheckerNiceName() string {
 	return a.checker.Name()
 }
 
+func (a *StatefulAgent) CheckerReason() string {
+	reasoner, ok := a.checker.(checkers.Reasoner)
+	if !ok {
+		return ""
+	}
+
+	return reasoner.Reason()
+}
+
 func (a *StatefulAgent) StreakUntilOkState() int {
 	return a.streakUntilOk
 }
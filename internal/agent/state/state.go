@@ -50,4 +50,5 @@ type Agent interface {
 	GetStateDuration() time.Duration
 	Run(ctx context.Context, req chan Agent) error
 	CheckerNiceName() string
+	CheckerReason() string
 }
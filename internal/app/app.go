@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/soerenschneider/conditional-reboot/internal"
+	"github.com/soerenschneider/conditional-reboot/internal/agent/state"
 	"github.com/soerenschneider/conditional-reboot/internal/group"
 	"github.com/soerenschneider/conditional-reboot/internal/journal"
 	"github.com/soerenschneider/conditional-reboot/internal/uptime"
@@ -119,8 +121,9 @@ func (app *ConditionalReboot) tryReboot(group *group.Group) error {
 		return nil
 	}
 
-	log.Info().Msg("Trying to reboot...")
-	if err := app.audit.Journal(actionToText(group)); err != nil {
+	action := actionToText(group)
+	log.Info().Msgf("Trying to reboot: %s", action)
+	if err := app.audit.Journal(action); err != nil {
 		log.Err(err).Msg("could not write journal")
 	}
 
@@ -130,5 +133,21 @@ func (app *ConditionalReboot) tryReboot(group *group.Group) error {
 func actionToText(g *group.Group) string {
 	now := time.Now()
 	formattedTime := now.Format("2006-01-02T15:04:05-07:00")
-	return fmt.Sprintf("%s Group '%s' requested reboot", formattedTime, g.GetName())
+	text := fmt.Sprintf("%s Group '%s' requested reboot", formattedTime, g.GetName())
+
+	var reasons []string
+	for _, agent := range g.Agents() {
+		if agent.GetState().Name() != state.RebootStateName {
+			continue
+		}
+
+		if reason := agent.CheckerReason(); len(reason) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", agent.CheckerNiceName(), reason))
+		}
+	}
+
+	if len(reasons) > 0 {
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(reasons, "; "))
+	}
+	return text
 }
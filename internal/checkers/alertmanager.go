@@ -0,0 +1,185 @@
+package checkers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	AlertmanagerCheckerName      = "alertmanager"
+	defaultAlertmanagerTimeout   = 10 * time.Second
+	alertmanagerAlertsPath       = "/api/v2/alerts"
+	alertmanagerAlertStateActive = "active"
+	alertmanagerMaxBodySize      = 16 << 20
+)
+
+type alertmanagerAlert struct {
+	Labels map[string]string `json:"labels"`
+	Status struct {
+		State       string   `json:"state"`
+		SilencedBy  []string `json:"silencedBy"`
+		InhibitedBy []string `json:"inhibitedBy"`
+	} `json:"status"`
+}
+
+// AlertmanagerChecker reports an unhealthy state if Alertmanager knows active alerts that match all configured label
+// matchers and that are neither silenced nor inhibited. Matchers use Alertmanager's syntax, e.g.
+// 'alertname="RebootRequired"', and may contain host templates such as '{{ .Hostname }}'.
+type AlertmanagerChecker struct {
+	address        string
+	matchers       []string
+	clientSettings httpClientSettings
+	timeout        time.Duration
+	client         *http.Client
+
+	mutex  sync.Mutex
+	reason string
+}
+
+type AlertmanagerOpts func(checker *AlertmanagerChecker) error
+
+func NewAlertmanagerChecker(address string, matchers []string, opts ...AlertmanagerOpts) (*AlertmanagerChecker, error) {
+	if len(address) == 0 {
+		return nil, errors.New("empty 'address' supplied")
+	}
+
+	if len(matchers) == 0 {
+		return nil, errors.New("no 'matchers' supplied")
+	}
+
+	checker := &AlertmanagerChecker{
+		address: strings.TrimSuffix(address, "/"),
+		timeout: defaultAlertmanagerTimeout,
+	}
+
+	for _, matcher := range matchers {
+		rendered, err := renderHostTemplate(matcher)
+		if err != nil {
+			return nil, fmt.Errorf("could not render matcher '%s': %w", matcher, err)
+		}
+		checker.matchers = append(checker.matchers, rendered)
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	client, err := buildRetryableHttpClient(checker.clientSettings)
+	if err != nil {
+		return nil, fmt.Errorf("could not build alertmanager client: %w", err)
+	}
+	checker.client = client
+
+	return checker, nil
+}
+
+func (c *AlertmanagerChecker) Name() string {
+	return fmt.Sprintf("%s - %s {%s}", AlertmanagerCheckerName, c.address, strings.Join(c.matchers, ", "))
+}
+
+func (c *AlertmanagerChecker) IsHealthy(ctx context.Context) (bool, error) {
+	alerts, err := c.fetchAlerts(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var firing []string
+	for _, alert := range alerts {
+		// the filter parameters of the API are applied again, older versions ignore some of them
+		if alert.Status.State != alertmanagerAlertStateActive || len(alert.Status.SilencedBy) > 0 || len(alert.Status.InhibitedBy) > 0 {
+			continue
+		}
+		firing = append(firing, formatAlertLabels(alert.Labels))
+	}
+
+	if len(firing) == 0 {
+		c.setReason("")
+		return true, nil
+	}
+
+	c.setReason(fmt.Sprintf("active alerts: %s", strings.Join(firing, ", ")))
+	log.Info().Str("checker", "alertmanager").Strs("alerts", firing).Msgf("Found %d matching active alerts", len(firing))
+	return false, nil
+}
+
+func (c *AlertmanagerChecker) setReason(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reason = reason
+}
+
+func (c *AlertmanagerChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
+
+func (c *AlertmanagerChecker) fetchAlerts(ctx context.Context) ([]alertmanagerAlert, error) {
+	params := url.Values{}
+	params.Set("active", "true")
+	params.Set("silenced", "false")
+	params.Set("inhibited", "false")
+	for _, matcher := range c.matchers {
+		params.Add("filter", matcher)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.address+alertmanagerAlertsPath+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not query alertmanager: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("alertmanager replied with status %d", resp.StatusCode)
+	}
+
+	var alerts []alertmanagerAlert
+	if err := json.NewDecoder(io.LimitReader(resp.Body, alertmanagerMaxBodySize)).Decode(&alerts); err != nil {
+		return nil, fmt.Errorf("could not decode alertmanager response: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func formatAlertLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
@@ -0,0 +1,61 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// alertmanagerHttpClient sets the TLS, authentication, proxy and network binding settings of the client.
+func alertmanagerHttpClient(settings httpClientSettings) AlertmanagerOpts {
+	return func(checker *AlertmanagerChecker) error {
+		checker.clientSettings = settings
+		return nil
+	}
+}
+
+func AlertmanagerTimeout(timeout time.Duration) AlertmanagerOpts {
+	return func(checker *AlertmanagerChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+func AlertmanagerCheckerFromMap(args map[string]any) (*AlertmanagerChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build alertmanager checker, empty args supplied")
+	}
+
+	address, ok := args["address"].(string)
+	if !ok {
+		return nil, errors.New("could not build alertmanager checker, no 'address' supplied")
+	}
+
+	matchers, ok, err := stringSliceFromArgs(args, "matchers")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("could not build alertmanager checker, no 'matchers' supplied")
+	}
+
+	settings, err := httpClientSettingsFromArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("could not build alertmanager checker: %w", err)
+	}
+	opts := []AlertmanagerOpts{alertmanagerHttpClient(settings)}
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, AlertmanagerTimeout(timeout))
+	}
+
+	return NewAlertmanagerChecker(address, matchers, opts...)
+}
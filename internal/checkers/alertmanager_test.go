@@ -0,0 +1,211 @@
+package checkers
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAlertmanagerChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		status     int
+		want       bool
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:     "no alerts",
+			response: `[]`,
+			want:     true,
+		},
+		{
+			name: "active alert",
+			response: `[{"labels":{"alertname":"RebootRequired","instance":"node-1"},
+				"status":{"state":"active","silencedBy":[],"inhibitedBy":[]}}]`,
+			want:       false,
+			wantReason: `active alerts: {alertname="RebootRequired", instance="node-1"}`,
+		},
+		{
+			name: "silenced alert",
+			response: `[{"labels":{"alertname":"RebootRequired","instance":"node-1"},
+				"status":{"state":"suppressed","silencedBy":["1234"],"inhibitedBy":[]}}]`,
+			want: true,
+		},
+		{
+			name: "inhibited alert",
+			response: `[{"labels":{"alertname":"RebootRequired","instance":"node-1"},
+				"status":{"state":"suppressed","silencedBy":[],"inhibitedBy":["5678"]}}]`,
+			want: true,
+		},
+		{
+			name:     "client error",
+			response: `bad request`,
+			status:   http.StatusBadRequest,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid response",
+			response: `{"alerts": []}`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilters []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != alertmanagerAlertsPath {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				gotFilters = r.URL.Query()["filter"]
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			matchers := []string{`alertname="RebootRequired"`, `instance="node-1"`}
+			c, err := NewAlertmanagerChecker(srv.URL+"/", matchers)
+			if err != nil {
+				t.Fatalf("NewAlertmanagerChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+			if !reflect.DeepEqual(gotFilters, matchers) {
+				t.Errorf("filters = %v, want %v", gotFilters, matchers)
+			}
+		})
+	}
+}
+
+func TestAlertmanagerChecker_IsHealthy_Client(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	writeFixture(t, tokenFile, "s3cr3t\n")
+	passwordFile := filepath.Join(dir, "password")
+	writeFixture(t, passwordFile, "hunter2\n")
+
+	tests := []struct {
+		name       string
+		args       map[string]any
+		wantHeader string
+	}{
+		{
+			name:       "bearer token",
+			args:       map[string]any{"bearer_token_file": tokenFile},
+			wantHeader: "Bearer s3cr3t",
+		},
+		{
+			name:       "basic auth",
+			args:       map[string]any{"username": "alertmanager", "password_file": passwordFile},
+			wantHeader: "Basic YWxlcnRtYW5hZ2VyOmh1bnRlcjI=",
+		},
+		{
+			name: "no auth",
+			args: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader string
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Get("Authorization")
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer srv.Close()
+
+			caFile := filepath.Join(dir, "ca.pem")
+			writeFixture(t, caFile, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})))
+
+			tt.args["address"] = srv.URL
+			tt.args["matchers"] = []any{`alertname="RebootRequired"`}
+			tt.args["tls_ca"] = caFile
+			c, err := AlertmanagerCheckerFromMap(tt.args)
+			if err != nil {
+				t.Fatalf("AlertmanagerCheckerFromMap() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Fatalf("IsHealthy() error = %v", err)
+			}
+			if !got {
+				t.Errorf("IsHealthy() got = %v, want true", got)
+			}
+			if gotHeader != tt.wantHeader {
+				t.Errorf("Authorization header = %q, want %q", gotHeader, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestNewAlertmanagerChecker_HostTemplate(t *testing.T) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("could not determine hostname: %v", err)
+	}
+
+	c, err := NewAlertmanagerChecker("http://localhost:9093", []string{`instance=~"{{ .Hostname }}(:.*)?"`})
+	if err != nil {
+		t.Fatalf("NewAlertmanagerChecker() error = %v", err)
+	}
+
+	want := []string{`instance=~"` + hostname + `(:.*)?"`}
+	if !reflect.DeepEqual(c.matchers, want) {
+		t.Errorf("matchers = %v, want %v", c.matchers, want)
+	}
+}
+
+func TestAlertmanagerCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: map[string]any{"address": "http://localhost:9093", "matchers": []any{`alertname="RebootRequired"`}, "timeout": "5s"},
+		},
+		{
+			name:    "no matchers",
+			args:    map[string]any{"address": "http://localhost:9093"},
+			wantErr: true,
+		},
+		{
+			name:    "password without username",
+			args:    map[string]any{"address": "http://localhost:9093", "matchers": []any{`alertname="RebootRequired"`}, "password": "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "client key without cert",
+			args:    map[string]any{"address": "http://localhost:9093", "matchers": []any{`alertname="RebootRequired"`}, "tls_client_key": "/tmp/key.pem"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AlertmanagerCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AlertmanagerCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
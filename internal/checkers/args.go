@@ -0,0 +1,147 @@
+package checkers
+
+import (
+	"fmt"
+	"time"
+)
+
+// durationFromArgs parses the human-readable duration stored under key. The returned bool indicates whether the key
+// was set at all.
+func durationFromArgs(args map[string]any, key string) (time.Duration, bool, error) {
+	val, ok := args[key]
+	if !ok {
+		return 0, false, nil
+	}
+
+	durationHuman, ok := val.(string)
+	if !ok {
+		return 0, true, fmt.Errorf("'%s' is not a duration string", key)
+	}
+
+	duration, err := time.ParseDuration(durationHuman)
+	if err != nil {
+		return 0, true, fmt.Errorf("'%s' could not be parsed: %w", key, err)
+	}
+
+	return duration, true, nil
+}
+
+// intFromArgs returns the number stored under key. Numbers are decoded either as int or float64, depending on the
+// format of the config file.
+func intFromArgs(args map[string]any, key string) (int, bool, error) {
+	val, ok := args[key]
+	if !ok {
+		return 0, false, nil
+	}
+
+	switch num := val.(type) {
+	case int:
+		return num, true, nil
+	case float64:
+		return int(num), true, nil
+	}
+
+	return 0, true, fmt.Errorf("'%s' is not a number", key)
+}
+
+// floatFromArgs returns the number stored under key as float64.
+func floatFromArgs(args map[string]any, key string) (float64, bool, error) {
+	val, ok := args[key]
+	if !ok {
+		return 0, false, nil
+	}
+
+	switch num := val.(type) {
+	case int:
+		return float64(num), true, nil
+	case float64:
+		return num, true, nil
+	}
+
+	return 0, true, fmt.Errorf("'%s' is not a number", key)
+}
+
+// stringSliceFromArgs returns the list of strings stored under key. Non-string items are formatted as strings.
+func stringSliceFromArgs(args map[string]any, key string) ([]string, bool, error) {
+	val, ok := args[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch items := val.(type) {
+	case []string:
+		return items, true, nil
+	case []any:
+		ret := make([]string, 0, len(items))
+		for _, item := range items {
+			ret = append(ret, fmt.Sprintf("%v", item))
+		}
+		return ret, true, nil
+	}
+
+	return nil, true, fmt.Errorf("'%s' is not a list", key)
+}
+
+// stringMapFromArgs returns the map stored under key. Non-string values are formatted as strings.
+func stringMapFromArgs(args map[string]any, key string) (map[string]string, bool, error) {
+	val, ok := args[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	switch items := val.(type) {
+	case map[string]string:
+		return items, true, nil
+	case map[string]any:
+		ret := make(map[string]string, len(items))
+		for k, v := range items {
+			ret[k] = fmt.Sprintf("%v", v)
+		}
+		return ret, true, nil
+	case map[any]any:
+		// yaml decodes maps with non-string keys, e.g. numbers, this way
+		ret := make(map[string]string, len(items))
+		for k, v := range items {
+			ret[fmt.Sprintf("%v", k)] = fmt.Sprintf("%v", v)
+		}
+		return ret, true, nil
+	}
+
+	return nil, true, fmt.Errorf("'%s' is not a map", key)
+}
+
+// floatMapFromArgs returns the map of numbers stored under key.
+func floatMapFromArgs(args map[string]any, key string) (map[string]float64, bool, error) {
+	val, ok := args[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	items := map[string]any{}
+	switch raw := val.(type) {
+	case map[string]float64:
+		return raw, true, nil
+	case map[string]any:
+		items = raw
+	case map[any]any:
+		for k, v := range raw {
+			items[fmt.Sprintf("%v", k)] = v
+		}
+	default:
+		return nil, true, fmt.Errorf("'%s' is not a map", key)
+	}
+
+	ret := make(map[string]float64, len(items))
+	for k, v := range items {
+		switch num := v.(type) {
+		case int:
+			ret[k] = float64(num)
+		case float64:
+			ret[k] = num
+		default:
+			return nil, true, fmt.Errorf("value of '%s' in '%s' is not a number", k, key)
+		}
+	}
+
+	return ret, true, nil
+}
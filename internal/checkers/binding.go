@@ -0,0 +1,124 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+)
+
+const netnsRunDir = "/run/netns"
+
+// NetworkBinding pins the traffic of network checkers to a source address, an interface or a network namespace, e.g.
+// to check the connectivity of a single uplink of a multi-homed router instead of any path. The zero value does not
+// restrict anything.
+type NetworkBinding struct {
+	SourceIp  string
+	Interface string
+	// Netns is either the name of a namespace created by 'ip netns' or the path to a namespace file.
+	Netns string
+}
+
+func (b NetworkBinding) IsZero() bool {
+	return len(b.SourceIp) == 0 && len(b.Interface) == 0 && len(b.Netns) == 0
+}
+
+func (b NetworkBinding) validate() error {
+	if len(b.SourceIp) > 0 && net.ParseIP(b.SourceIp) == nil {
+		return fmt.Errorf("invalid source ip %q", b.SourceIp)
+	}
+
+	if (len(b.Interface) > 0 || len(b.Netns) > 0) && !networkBindingSupported {
+		return errors.New("binding to an interface or a network namespace is only supported on linux")
+	}
+
+	if len(b.Netns) > 0 && !filepath.IsAbs(b.Netns) && strings.ContainsRune(b.Netns, filepath.Separator) {
+		return fmt.Errorf("invalid network namespace %q, expected a name or an absolute path", b.Netns)
+	}
+
+	return nil
+}
+
+// String returns the binding in the notation of iproute2, e.g. 'dev wan0 src 192.0.2.1'.
+func (b NetworkBinding) String() string {
+	var parts []string
+	if len(b.Netns) > 0 {
+		parts = append(parts, "netns", b.Netns)
+	}
+	if len(b.Interface) > 0 {
+		parts = append(parts, "dev", b.Interface)
+	}
+	if len(b.SourceIp) > 0 {
+		parts = append(parts, "src", b.SourceIp)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (b NetworkBinding) netnsPath() string {
+	if filepath.IsAbs(b.Netns) {
+		return b.Netns
+	}
+
+	return filepath.Join(netnsRunDir, b.Netns)
+}
+
+func (b NetworkBinding) dialer(network string) *net.Dialer {
+	dialer := &net.Dialer{}
+
+	if ip := net.ParseIP(b.SourceIp); ip != nil {
+		switch {
+		case strings.HasPrefix(network, "tcp"):
+			dialer.LocalAddr = &net.TCPAddr{IP: ip}
+		case strings.HasPrefix(network, "udp"):
+			dialer.LocalAddr = &net.UDPAddr{IP: ip}
+		}
+	}
+
+	if len(b.Interface) > 0 {
+		dialer.Control = bindToDeviceControl(b.Interface)
+	}
+
+	if len(b.Netns) > 0 {
+		// fast fallback dials from additional goroutines which would not run in the namespace
+		dialer.FallbackDelay = -1
+	}
+
+	return dialer
+}
+
+// DialContext dials the address according to the binding. Host names are resolved using the resolver configuration
+// of the host, not of the network namespace, so addresses should be preferred when using a namespace.
+func (b NetworkBinding) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := b.dialer(network)
+
+	var conn net.Conn
+	err := b.run(func() error {
+		var err error
+		conn, err = dialer.DialContext(ctx, network, address)
+		return err
+	})
+
+	return conn, err
+}
+
+// run executes fn inside the configured network namespace. Sockets keep the namespace they were created in, so
+// connections opened by fn can be used afterwards from any goroutine.
+func (b NetworkBinding) run(fn func() error) error {
+	if len(b.Netns) == 0 {
+		return fn()
+	}
+
+	return runInNetns(b.netnsPath(), fn)
+}
+
+func networkBindingFromArgs(args map[string]any) (NetworkBinding, bool) {
+	var binding NetworkBinding
+	binding.SourceIp, _ = args["source_ip"].(string)
+	binding.Interface, _ = args["interface"].(string)
+	binding.Netns, _ = args["netns"].(string)
+
+	return binding, !binding.IsZero()
+}
@@ -0,0 +1,111 @@
+//go:build linux
+
+package checkers
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const networkBindingSupported = true
+
+func bindToDeviceControl(iface string) func(network, address string, conn syscall.RawConn) error {
+	return func(_, _ string, conn syscall.RawConn) error {
+		var sockErr error
+		err := conn.Control(func(fd uintptr) {
+			sockErr = unix.SetsockoptString(int(fd), unix.SOL_SOCKET, unix.SO_BINDTODEVICE, iface)
+		})
+		if err != nil {
+			return err
+		}
+		if sockErr != nil {
+			return fmt.Errorf("could not bind to interface %s: %w", iface, sockErr)
+		}
+
+		return nil
+	}
+}
+
+// listenIcmp opens an ICMP socket that is bound to the interface and, if given, the source address. Unprivileged
+// sockets are datagram oriented ping sockets, which require the group to be part of net.ipv4.ping_group_range.
+func listenIcmp(isIpv4, privileged bool, source, iface string) (net.PacketConn, error) {
+	family, proto := unix.AF_INET6, unix.IPPROTO_ICMPV6
+	if isIpv4 {
+		family, proto = unix.AF_INET, unix.IPPROTO_ICMP
+	}
+
+	sotype := unix.SOCK_DGRAM
+	if privileged {
+		sotype = unix.SOCK_RAW
+	}
+
+	fd, err := unix.Socket(family, sotype|unix.SOCK_CLOEXEC, proto)
+	if err != nil {
+		return nil, fmt.Errorf("could not open icmp socket: %w", err)
+	}
+	// FilePacketConn duplicates the descriptor
+	file := os.NewFile(uintptr(fd), "icmp")
+	defer file.Close()
+
+	if len(iface) > 0 {
+		if err := unix.SetsockoptString(fd, unix.SOL_SOCKET, unix.SO_BINDTODEVICE, iface); err != nil {
+			return nil, fmt.Errorf("could not bind to interface %s: %w", iface, err)
+		}
+	}
+
+	if len(source) > 0 {
+		ip := net.ParseIP(source)
+		if (ip.To4() != nil) != isIpv4 {
+			return nil, fmt.Errorf("source ip %s does not match the address family of the host", source)
+		}
+
+		var sa unix.Sockaddr
+		if isIpv4 {
+			sa4 := &unix.SockaddrInet4{}
+			copy(sa4.Addr[:], ip.To4())
+			sa = sa4
+		} else {
+			sa6 := &unix.SockaddrInet6{}
+			copy(sa6.Addr[:], ip.To16())
+			sa = sa6
+		}
+		if err := unix.Bind(fd, sa); err != nil {
+			return nil, fmt.Errorf("could not bind to source ip %s: %w", source, err)
+		}
+	}
+
+	return net.FilePacketConn(file)
+}
+
+// runInNetns runs fn on a dedicated OS thread that has joined the network namespace. Once joined, the thread is not
+// unlocked, so the runtime terminates it instead of scheduling other goroutines on it.
+func runInNetns(path string, fn func() error) error {
+	result := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+
+		// #nosec G304
+		ns, err := os.Open(path)
+		if err != nil {
+			runtime.UnlockOSThread()
+			result <- fmt.Errorf("could not open network namespace: %w", err)
+			return
+		}
+		defer ns.Close()
+
+		if err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			result <- fmt.Errorf("could not enter network namespace %s: %w", path, err)
+			return
+		}
+
+		result <- fn()
+	}()
+
+	return <-result
+}
@@ -0,0 +1,25 @@
+//go:build !linux
+
+package checkers
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+const networkBindingSupported = false
+
+func bindToDeviceControl(_ string) func(network, address string, conn syscall.RawConn) error {
+	return func(_, _ string, _ syscall.RawConn) error {
+		return errors.New("binding to an interface is only supported on linux")
+	}
+}
+
+func listenIcmp(_, _ bool, _, _ string) (net.PacketConn, error) {
+	return nil, errors.New("binding to an interface is only supported on linux")
+}
+
+func runInNetns(_ string, _ func() error) error {
+	return errors.New("network namespaces are only supported on linux")
+}
@@ -0,0 +1,89 @@
+package checkers
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+)
+
+func TestNetworkBinding_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		binding NetworkBinding
+		wantErr bool
+	}{
+		{
+			name: "zero value",
+		},
+		{
+			name:    "source ip",
+			binding: NetworkBinding{SourceIp: "192.0.2.1"},
+		},
+		{
+			name:    "invalid source ip",
+			binding: NetworkBinding{SourceIp: "192.0.2"},
+			wantErr: true,
+		},
+		{
+			name:    "netns name",
+			binding: NetworkBinding{Netns: "wan"},
+			wantErr: !networkBindingSupported,
+		},
+		{
+			name:    "netns path",
+			binding: NetworkBinding{Netns: "/var/run/netns/wan"},
+			wantErr: !networkBindingSupported,
+		},
+		{
+			name:    "relative netns path",
+			binding: NetworkBinding{Netns: "netns/wan"},
+			wantErr: true,
+		},
+		{
+			name:    "interface",
+			binding: NetworkBinding{Interface: "wan0"},
+			wantErr: !networkBindingSupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.binding.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNetworkBinding_String(t *testing.T) {
+	binding := NetworkBinding{SourceIp: "192.0.2.1", Interface: "wan0", Netns: "wan"}
+	want := "netns wan dev wan0 src 192.0.2.1"
+	if got := binding.String(); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
+
+func TestNetworkBinding_DialContext(t *testing.T) {
+	listener := listenTcp(t)
+	host, port := startTcpServer(t, listener, nil)
+	address := net.JoinHostPort(host, port)
+
+	conn, err := NetworkBinding{SourceIp: "127.0.0.1"}.DialContext(context.Background(), "tcp", address)
+	if err != nil {
+		t.Fatalf("DialContext() error = %v", err)
+	}
+	defer conn.Close()
+
+	if ip := conn.LocalAddr().(*net.TCPAddr).IP.String(); ip != "127.0.0.1" {
+		t.Errorf("DialContext() local address = %v, want 127.0.0.1", ip)
+	}
+
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	_, err = NetworkBinding{Interface: "nonexistent0"}.DialContext(context.Background(), "tcp", address)
+	if err == nil {
+		t.Errorf("DialContext() expected error for unknown interface")
+	}
+}
@@ -8,3 +8,9 @@ type Checker interface {
 	IsHealthy(ctx context.Context) (bool, error)
 	Name() string
 }
+
+// Reasoner is optionally implemented by checkers that are able to explain why they consider a reboot necessary.
+type Reasoner interface {
+	// Reason returns a human-readable explanation of the last unhealthy result or an empty string.
+	Reason() string
+}
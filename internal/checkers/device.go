@@ -0,0 +1,182 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const DeviceCheckerName = "device"
+
+var (
+	deviceIdRegex  = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{4}$`)
+	pciAddrRegex   = regexp.MustCompile(`^([0-9a-fA-F]{4}:)?[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+	pciDomainRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:`)
+)
+
+// DeviceChecker reports an unhealthy state if configured PCI or USB devices are no longer present in sysfs, e.g.
+// because a buggy NIC or LTE modem fell off the bus. PCI devices are given as 'vendor:device' id, as address, such as
+// '0000:01:00.0', or as sysfs path, USB devices as 'vendor:product' id.
+type DeviceChecker struct {
+	pciDevices []string
+	usbDevices []string
+	sysfsRoot  string
+
+	mutex   sync.Mutex
+	missing []string
+}
+
+type DeviceOpts func(checker *DeviceChecker) error
+
+func NewDeviceChecker(opts ...DeviceOpts) (*DeviceChecker, error) {
+	checker := &DeviceChecker{
+		sysfsRoot: defaultSysfsRoot,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if len(checker.pciDevices) == 0 && len(checker.usbDevices) == 0 {
+		errs = multierr.Append(errs, errors.New("neither pci nor usb devices provided"))
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return checker, nil
+}
+
+func (c *DeviceChecker) Name() string {
+	var devices []string
+	for _, dev := range c.pciDevices {
+		devices = append(devices, "pci:"+dev)
+	}
+	for _, dev := range c.usbDevices {
+		devices = append(devices, "usb:"+dev)
+	}
+
+	return fmt.Sprintf("%s://%s", DeviceCheckerName, strings.Join(devices, ","))
+}
+
+func (c *DeviceChecker) IsHealthy(_ context.Context) (bool, error) {
+	var missing []string
+
+	if len(c.pciDevices) > 0 {
+		dir := filepath.Join(c.sysfsRoot, "bus", "pci", "devices")
+		ids, err := readDeviceIds(dir, "vendor", "device")
+		if err != nil {
+			return false, err
+		}
+
+		matched := map[string]int{}
+		for _, dev := range c.pciDevices {
+			if !c.isPciDevicePresent(dir, dev, ids, matched) {
+				missing = append(missing, "pci:"+dev)
+			}
+		}
+	}
+
+	if len(c.usbDevices) > 0 {
+		ids, err := readDeviceIds(filepath.Join(c.sysfsRoot, "bus", "usb", "devices"), "idVendor", "idProduct")
+		if err != nil {
+			return false, err
+		}
+
+		// an id that is configured multiple times requires as many devices with that id
+		matched := map[string]int{}
+		for _, dev := range c.usbDevices {
+			matched[dev]++
+			if matched[dev] > ids[dev] {
+				missing = append(missing, "usb:"+dev)
+			}
+		}
+	}
+
+	c.mutex.Lock()
+	c.missing = missing
+	c.mutex.Unlock()
+
+	if len(missing) > 0 {
+		log.Warn().Str("checker", "device").Strs("missing", missing).Msgf("Checker '%s' detected missing devices", c.Name())
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (c *DeviceChecker) isPciDevicePresent(dir, dev string, ids, matched map[string]int) bool {
+	switch {
+	case deviceIdRegex.MatchString(dev):
+		// an id that is configured multiple times requires as many devices with that id
+		matched[dev]++
+		return matched[dev] <= ids[dev]
+	case pciAddrRegex.MatchString(dev):
+		return pathExists(filepath.Join(dir, dev))
+	default:
+		// sysfs paths are configured as seen on the host, so they are rebased onto the configured root
+		rel, err := filepath.Rel(defaultSysfsRoot, dev)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return pathExists(dev)
+		}
+		return pathExists(filepath.Join(c.sysfsRoot, rel))
+	}
+}
+
+func (c *DeviceChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.missing) == 0 {
+		return ""
+	}
+	return "missing devices: " + strings.Join(c.missing, ", ")
+}
+
+// readDeviceIds returns how many devices with each 'vendor:product' id are present on a bus. Entries without ids, such
+// as USB interfaces, are skipped.
+func readDeviceIds(dir, vendorFile, productFile string) (map[string]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read devices: %w", err)
+	}
+
+	ids := map[string]int{}
+	for _, entry := range entries {
+		vendor, err := readSysfsString(filepath.Join(dir, entry.Name(), vendorFile))
+		if err != nil {
+			continue
+		}
+
+		product, err := readSysfsString(filepath.Join(dir, entry.Name(), productFile))
+		if err != nil {
+			continue
+		}
+
+		ids[normalizeDeviceId(vendor+":"+product)]++
+	}
+
+	return ids, nil
+}
+
+// normalizeDeviceId converts ids such as '0x8086:0x15B8' to '8086:15b8'.
+func normalizeDeviceId(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "0x", ""))
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,95 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+func DeviceSysfsRoot(root string) DeviceOpts {
+	return func(checker *DeviceChecker) error {
+		if len(root) == 0 {
+			return errors.New("empty sysfs root provided")
+		}
+
+		checker.sysfsRoot = root
+		return nil
+	}
+}
+
+// DevicePci sets the PCI devices that need to be present, given as 'vendor:device' id, e.g. '8086:15b8', as address,
+// e.g. '0000:01:00.0' or '01:00.0', or as absolute sysfs path.
+func DevicePci(devices []string) DeviceOpts {
+	return func(checker *DeviceChecker) error {
+		if len(devices) == 0 {
+			return errors.New("no pci devices provided")
+		}
+
+		checker.pciDevices = make([]string, 0, len(devices))
+		for _, dev := range devices {
+			switch {
+			case deviceIdRegex.MatchString(dev):
+				dev = normalizeDeviceId(dev)
+			case pciAddrRegex.MatchString(dev):
+				dev = strings.ToLower(dev)
+				if !pciDomainRegex.MatchString(dev) {
+					dev = "0000:" + dev
+				}
+			case filepath.IsAbs(dev):
+				dev = filepath.Clean(dev)
+			default:
+				return fmt.Errorf("invalid pci device %q, expected 'vendor:device', address or sysfs path", dev)
+			}
+			checker.pciDevices = append(checker.pciDevices, dev)
+		}
+		return nil
+	}
+}
+
+// DeviceUsb sets the USB devices that need to be present, given as 'vendor:product' id, e.g. '12d1:1506'.
+func DeviceUsb(devices []string) DeviceOpts {
+	return func(checker *DeviceChecker) error {
+		if len(devices) == 0 {
+			return errors.New("no usb devices provided")
+		}
+
+		checker.usbDevices = make([]string, 0, len(devices))
+		for _, dev := range devices {
+			if !deviceIdRegex.MatchString(dev) {
+				return fmt.Errorf("invalid usb device %q, expected 'vendor:product'", dev)
+			}
+			checker.usbDevices = append(checker.usbDevices, normalizeDeviceId(dev))
+		}
+		return nil
+	}
+}
+
+func DeviceCheckerFromMap(args map[string]any) (*DeviceChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build device checker, empty args supplied")
+	}
+
+	var opts []DeviceOpts
+	if root, ok := args["sysfs_root"].(string); ok {
+		opts = append(opts, DeviceSysfsRoot(root))
+	}
+
+	pciDevices, ok, err := stringSliceFromArgs(args, "pci")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, DevicePci(pciDevices))
+	}
+
+	usbDevices, ok, err := stringSliceFromArgs(args, "usb")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, DeviceUsb(usbDevices))
+	}
+
+	return NewDeviceChecker(opts...)
+}
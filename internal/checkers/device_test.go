@@ -0,0 +1,161 @@
+package checkers
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeDeviceFixtures(t *testing.T, root string) {
+	t.Helper()
+	pci := filepath.Join(root, "bus", "pci", "devices")
+	writeFixture(t, filepath.Join(pci, "0000:00:00.0", "vendor"), "0x8086\n")
+	writeFixture(t, filepath.Join(pci, "0000:00:00.0", "device"), "0x3e34\n")
+	writeFixture(t, filepath.Join(pci, "0000:01:00.0", "vendor"), "0x8086\n")
+	writeFixture(t, filepath.Join(pci, "0000:01:00.0", "device"), "0x15B8\n")
+	// second port of a dual port NIC
+	writeFixture(t, filepath.Join(pci, "0000:01:00.1", "vendor"), "0x8086\n")
+	writeFixture(t, filepath.Join(pci, "0000:01:00.1", "device"), "0x15b8\n")
+	writeFixture(t, filepath.Join(root, "devices", "pci0000:00", "0000:00:1c.0", "0000:01:00.0", "vendor"), "0x8086\n")
+
+	usb := filepath.Join(root, "bus", "usb", "devices")
+	writeFixture(t, filepath.Join(usb, "1-1", "idVendor"), "12d1\n")
+	writeFixture(t, filepath.Join(usb, "1-1", "idProduct"), "1506\n")
+	// interfaces have no ids
+	writeFixture(t, filepath.Join(usb, "1-1:1.0", "bInterfaceClass"), "ff\n")
+}
+
+func TestDeviceChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []DeviceOpts
+		want       bool
+		wantReason string
+	}{
+		{
+			name: "pci id present",
+			opts: []DeviceOpts{DevicePci([]string{"8086:15b8"})},
+			want: true,
+		},
+		{
+			name: "pci address present",
+			opts: []DeviceOpts{DevicePci([]string{"01:00.0"})},
+			want: true,
+		},
+		{
+			name: "pci sysfs path present",
+			opts: []DeviceOpts{DevicePci([]string{"/sys/devices/pci0000:00/0000:00:1c.0/0000:01:00.0"})},
+			want: true,
+		},
+		{
+			name: "usb id present",
+			opts: []DeviceOpts{DeviceUsb([]string{"12D1:1506"})},
+			want: true,
+		},
+		{
+			name: "duplicated pci id present",
+			opts: []DeviceOpts{DevicePci([]string{"8086:15b8", "8086:15B8"})},
+			want: true,
+		},
+		{
+			name:       "duplicated pci id missing",
+			opts:       []DeviceOpts{DevicePci([]string{"8086:3e34", "8086:3e34"})},
+			want:       false,
+			wantReason: "missing devices: pci:8086:3e34",
+		},
+		{
+			name:       "duplicated usb id missing",
+			opts:       []DeviceOpts{DeviceUsb([]string{"12d1:1506", "12d1:1506"})},
+			want:       false,
+			wantReason: "missing devices: usb:12d1:1506",
+		},
+		{
+			name:       "devices missing",
+			opts:       []DeviceOpts{DevicePci([]string{"8086:15b8", "0000:02:00.0"}), DeviceUsb([]string{"12d1:1506", "1199:9071"})},
+			want:       false,
+			wantReason: "missing devices: pci:0000:02:00.0, usb:1199:9071",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeDeviceFixtures(t, root)
+
+			opts := append([]DeviceOpts{DeviceSysfsRoot(root)}, tt.opts...)
+			c, err := NewDeviceChecker(opts...)
+			if err != nil {
+				t.Fatalf("NewDeviceChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestDeviceChecker_IsHealthy_NoBus(t *testing.T) {
+	c, err := NewDeviceChecker(DeviceSysfsRoot(t.TempDir()), DeviceUsb([]string{"12d1:1506"}))
+	if err != nil {
+		t.Fatalf("NewDeviceChecker() error = %v", err)
+	}
+
+	if _, err := c.IsHealthy(context.Background()); err == nil {
+		t.Errorf("IsHealthy() expected error for missing bus")
+	}
+}
+
+func TestDeviceCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantPci []string
+		wantErr bool
+	}{
+		{
+			name:    "pci devices",
+			args:    map[string]any{"pci": []any{"8086:15B8", "01:00.0", "/sys/devices/pci0000:00/0000:00:1c.0/"}},
+			wantPci: []string{"8086:15b8", "0000:01:00.0", "/sys/devices/pci0000:00/0000:00:1c.0"},
+		},
+		{
+			name: "usb devices",
+			args: map[string]any{"usb": []any{"12d1:1506"}, "sysfs_root": "/host/sys"},
+		},
+		{
+			name:    "invalid pci device",
+			args:    map[string]any{"pci": []any{"eth0"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid usb device",
+			args:    map[string]any{"usb": []any{"01:00.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "no devices",
+			args:    map[string]any{"sysfs_root": "/host/sys"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeviceCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeviceCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.pciDevices, tt.wantPci) {
+				t.Errorf("DeviceCheckerFromMap() pci = %v, want %v", got.pciDevices, tt.wantPci)
+			}
+		})
+	}
+}
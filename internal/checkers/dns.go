@@ -1,52 +1,384 @@
 package checkers
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/miekg/dns"
 	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
 )
 
-const DnsCheckerName = "dns"
+const (
+	DnsCheckerName    = "dns"
+	defaultDnsTimeout = 5 * time.Second
+	defaultDnsPort    = "53"
+	defaultDotPort    = "853"
+	defaultResolvConf = "/etc/resolv.conf"
+	dohContentType    = "application/dns-message"
+	dohMaxBodySize    = 64 << 10
 
+	DnsNetworkUdp   = "udp"
+	DnsNetworkTcp   = "tcp"
+	DnsNetworkTls   = "tls"
+	DnsNetworkHttps = "https"
+)
+
+// DnsChecker queries a record of the given host and reports an unhealthy state if the server does not reply, replies
+// with an error or, if configured, replies without the expected answers. If no server is configured, the servers of
+// the system's resolv.conf are queried. Besides classic DNS, queries can be sent via DNS-over-TLS (RFC 7858) and
+// DNS-over-HTTPS (RFC 8484), for which servers are given as URL.
 type DnsChecker struct {
-	host string
+	host        string
+	servers     []string
+	network     string
+	recordType  uint16
+	expected    []string
+	noErrorOnly bool
+	timeout     time.Duration
+	resolvConf  string
+	serverName  string
+	caFile      string
+	tlsConfig   *tls.Config
+	httpClient  *http.Client
+	binding     NetworkBinding
+
+	mutex  sync.Mutex
+	reason string
 }
 
-func NewDnsChecker(host string) (*DnsChecker, error) {
+type DnsOpts func(checker *DnsChecker) error
+
+func NewDnsChecker(host string, opts ...DnsOpts) (*DnsChecker, error) {
 	if len(host) == 0 {
 		return nil, errors.New("empty host provided")
 	}
 
-	return &DnsChecker{host: host}, nil
+	checker := &DnsChecker{
+		host:       host,
+		network:    DnsNetworkUdp,
+		recordType: dns.TypeA,
+		timeout:    defaultDnsTimeout,
+		resolvConf: defaultResolvConf,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if err := checker.prepareTransport(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	return checker, errs
 }
 
-func DnsCheckerFromMap(args map[string]any) (*DnsChecker, error) {
-	if args == nil {
-		return nil, errors.New("empty args supplied")
+// prepareTransport adds the default port of the configured network to servers and builds the TLS config for DoT and
+// DoH.
+func (c *DnsChecker) prepareTransport() error {
+	defaultPort := defaultDnsPort
+	switch c.network {
+	case DnsNetworkTls:
+		defaultPort = defaultDotPort
+	case DnsNetworkHttps:
+		for _, server := range c.servers {
+			if !strings.HasPrefix(server, "https://") {
+				return fmt.Errorf("server %q is not a https url", server)
+			}
+		}
+	}
+
+	if len(c.servers) == 0 && (c.network == DnsNetworkTls || c.network == DnsNetworkHttps) {
+		return fmt.Errorf("network %q requires servers", c.network)
+	}
+
+	if c.network != DnsNetworkHttps {
+		for idx, server := range c.servers {
+			if _, _, err := net.SplitHostPort(server); err != nil {
+				c.servers[idx] = net.JoinHostPort(server, defaultPort)
+			}
+		}
+	}
+
+	if c.network != DnsNetworkTls && c.network != DnsNetworkHttps {
+		if len(c.serverName) > 0 || len(c.caFile) > 0 {
+			return fmt.Errorf("server name and CA file are not supported for network %q", c.network)
+		}
+		return nil
 	}
 
-	host, ok := args["host"]
-	if !ok {
-		return nil, errors.New("no 'host' supplied")
+	tlsConfig, err := buildTlsConfig(c.caFile, "", "")
+	if err != nil {
+		return err
 	}
+	tlsConfig.ServerName = c.serverName
+	c.tlsConfig = tlsConfig
 
-	return NewDnsChecker(fmt.Sprintf("%s", host))
+	if c.network == DnsNetworkHttps {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		if !c.binding.IsZero() {
+			transport.DialContext = c.binding.DialContext
+		}
+		c.httpClient = &http.Client{Transport: transport}
+	}
+
+	return nil
 }
 
 func (c *DnsChecker) Name() string {
-	return fmt.Sprintf("%s://%s", DnsCheckerName, c.host)
+	details := dns.TypeToString[c.recordType]
+	if !c.binding.IsZero() {
+		details += ", " + c.binding.String()
+	}
+
+	if len(c.servers) == 0 {
+		return fmt.Sprintf("%s://%s (%s)", DnsCheckerName, c.host, details)
+	}
+	return fmt.Sprintf("%s://%s/%s (%s)", DnsCheckerName, strings.Join(c.servers, ","), c.host, details)
+}
+
+func (c *DnsChecker) IsHealthy(ctx context.Context) (bool, error) {
+	servers, err := c.getServers()
+	if err != nil {
+		return false, err
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(c.host), c.recordType)
+
+	var errs error
+	for _, server := range servers {
+		reply, err := c.exchange(ctx, msg, server)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", server, err))
+			continue
+		}
+
+		if err := c.evaluateReply(reply); err != nil {
+			c.setReason(fmt.Sprintf("%s: %v", server, err))
+			log.Warn().Str("checker", "dns").Msgf("Checker '%s' received unexpected reply from %s: %v", c.Name(), server, err)
+			return false, nil
+		}
+
+		log.Debug().Str("checker", "dns").Msgf("Received reply for checker '%s' from %s", c.Name(), server)
+		c.setReason("")
+		return true, nil
+	}
+
+	c.setReason(fmt.Sprintf("no reply: %v", errs))
+	log.Error().Err(errs).Str("checker", "dns").Msgf("Connectivity checker '%s' did not receive a reply", c.Name())
+	return false, nil
+}
+
+func (c *DnsChecker) setReason(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reason = reason
+}
+
+func (c *DnsChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
+
+func (c *DnsChecker) getServers() ([]string, error) {
+	if len(c.servers) > 0 {
+		return c.servers, nil
+	}
+
+	conf, err := dns.ClientConfigFromFile(c.resolvConf)
+	if err != nil {
+		return nil, fmt.Errorf("could not read resolv.conf: %w", err)
+	}
+
+	servers := make([]string, 0, len(conf.Servers))
+	for _, server := range conf.Servers {
+		servers = append(servers, net.JoinHostPort(server, conf.Port))
+	}
+
+	if len(servers) == 0 {
+		return nil, errors.New("no nameservers found in resolv.conf")
+	}
+
+	return servers, nil
+}
+
+func (c *DnsChecker) exchange(ctx context.Context, msg *dns.Msg, server string) (*dns.Msg, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if c.network == DnsNetworkHttps {
+		return c.exchangeHttps(ctx, msg, server)
+	}
+
+	reply, err := c.exchangeConn(ctx, msg, c.network, server)
+	if err != nil {
+		return nil, err
+	}
+
+	// retry truncated replies via tcp
+	if reply.Truncated && c.network == DnsNetworkUdp {
+		return c.exchangeConn(ctx, msg, DnsNetworkTcp, server)
+	}
+
+	return reply, nil
+}
+
+// exchangeConn dials the server according to the network binding and sends the query via udp, tcp or tls.
+func (c *DnsChecker) exchangeConn(ctx context.Context, msg *dns.Msg, network, server string) (*dns.Msg, error) {
+	dialNetwork := network
+	if network == DnsNetworkTls {
+		dialNetwork = DnsNetworkTcp
+	}
+
+	conn, err := c.binding.DialContext(ctx, dialNetwork, server)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == DnsNetworkTls {
+		tlsConfig := c.tlsConfig.Clone()
+		if len(tlsConfig.ServerName) == 0 {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(server)
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("tls handshake failed: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	dnsConn := &dns.Conn{Conn: conn}
+	defer dnsConn.Close()
+
+	client := &dns.Client{Net: network, Timeout: c.timeout}
+	reply, _, err := client.ExchangeWithConnContext(ctx, msg, dnsConn)
+	return reply, err
 }
 
-func (c *DnsChecker) IsHealthy(_ context.Context) (bool, error) {
-	ips, err := net.LookupIP(c.host)
+// exchangeHttps sends the query as POST request according to RFC 8484.
+func (c *DnsChecker) exchangeHttps(ctx context.Context, msg *dns.Msg, server string) (*dns.Msg, error) {
+	// the id should be 0 to improve cache friendliness
+	query := msg.Copy()
+	query.Id = 0
+
+	packed, err := query.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("could not pack query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server replied with status %d", resp.StatusCode)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != dohContentType {
+		return nil, fmt.Errorf("unexpected content type %q", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dohMaxBodySize))
 	if err != nil {
-		log.Error().Err(err).Str("checker", "dns").Msgf("Connectivity checker '%s' reported error", err)
-		return false, nil
+		return nil, err
+	}
+
+	reply := new(dns.Msg)
+	if err := reply.Unpack(body); err != nil {
+		return nil, fmt.Errorf("could not unpack reply: %w", err)
+	}
+
+	return reply, nil
+}
+
+func (c *DnsChecker) evaluateReply(reply *dns.Msg) error {
+	if reply.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("rcode %s", dns.RcodeToString[reply.Rcode])
+	}
+
+	if c.noErrorOnly {
+		return nil
+	}
+
+	var answers []string
+	for _, rr := range reply.Answer {
+		if rr.Header().Rrtype != c.recordType {
+			continue
+		}
+		answers = append(answers, formatDnsAnswer(rr))
+	}
+
+	if len(answers) == 0 {
+		return errors.New("no answers")
+	}
+
+	var missing []string
+	for _, expected := range c.expected {
+		found := false
+		for _, answer := range answers {
+			if strings.EqualFold(normalizeDnsAnswer(answer), normalizeDnsAnswer(expected)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, expected)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("expected answers %v not found in %v", missing, answers)
 	}
 
-	log.Debug().Str("checker", "dns").Msgf("Received reply for checker '%s': %v", c.Name(), ips)
-	return true, nil
+	return nil
+}
+
+// formatDnsAnswer returns the data of a record in the format it is configured as expected answer.
+func formatDnsAnswer(rr dns.RR) string {
+	switch record := rr.(type) {
+	case *dns.A:
+		return record.A.String()
+	case *dns.AAAA:
+		return record.AAAA.String()
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", record.Preference, record.Mx)
+	case *dns.TXT:
+		return strings.Join(record.Txt, "")
+	case *dns.SOA:
+		return record.Ns
+	}
+
+	// strip the header, e.g. 'example.com. 300 IN CNAME'
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// normalizeDnsAnswer strips trailing dots of names, so 'mx.example.com' matches 'mx.example.com.'.
+func normalizeDnsAnswer(answer string) string {
+	return strings.TrimSuffix(strings.TrimSpace(answer), ".")
 }
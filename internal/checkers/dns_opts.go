@@ -0,0 +1,186 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// DnsServers sets the servers that are queried in order until one replies. Servers without port use port 53, or 853
+// for DNS-over-TLS. DNS-over-HTTPS servers are given as URL, e.g. 'https://dns.example.com/dns-query'.
+func DnsServers(servers []string) DnsOpts {
+	return func(checker *DnsChecker) error {
+		if len(servers) == 0 {
+			return errors.New("no servers provided")
+		}
+
+		checker.servers = append([]string{}, servers...)
+		return nil
+	}
+}
+
+func DnsNetwork(network string) DnsOpts {
+	return func(checker *DnsChecker) error {
+		switch network {
+		case DnsNetworkUdp, DnsNetworkTcp, DnsNetworkTls, DnsNetworkHttps:
+			checker.network = network
+			return nil
+		}
+
+		return fmt.Errorf("unsupported network %q", network)
+	}
+}
+
+// DnsRecordType sets the type of the queried record, such as 'A', 'AAAA', 'MX', 'TXT' or 'SOA'.
+func DnsRecordType(recordType string) DnsOpts {
+	return func(checker *DnsChecker) error {
+		parsed, ok := dns.StringToType[strings.ToUpper(recordType)]
+		if !ok {
+			return fmt.Errorf("unknown record type %q", recordType)
+		}
+
+		checker.recordType = parsed
+		return nil
+	}
+}
+
+// DnsExpectedAnswers sets answers that all need to be part of the reply. Answers are formatted as IP addresses for
+// A and AAAA records, as '<preference> <host>' for MX records, as text for TXT records and as primary nameserver
+// for SOA records.
+func DnsExpectedAnswers(expected []string) DnsOpts {
+	return func(checker *DnsChecker) error {
+		if len(expected) == 0 {
+			return errors.New("no expected answers provided")
+		}
+
+		checker.expected = expected
+		return nil
+	}
+}
+
+// DnsNoErrorOnly only checks for the NOERROR rcode, replies without answers are considered healthy.
+func DnsNoErrorOnly(noErrorOnly bool) DnsOpts {
+	return func(checker *DnsChecker) error {
+		checker.noErrorOnly = noErrorOnly
+		return nil
+	}
+}
+
+// DnsServerName sets the name that is sent via SNI and verified against the server's certificate for DNS-over-TLS and
+// DNS-over-HTTPS. It defaults to the host of the server.
+func DnsServerName(serverName string) DnsOpts {
+	return func(checker *DnsChecker) error {
+		if len(serverName) == 0 {
+			return errors.New("empty server name provided")
+		}
+
+		checker.serverName = serverName
+		return nil
+	}
+}
+
+func DnsCaFile(caFile string) DnsOpts {
+	return func(checker *DnsChecker) error {
+		if len(caFile) == 0 {
+			return errors.New("empty CA file provided")
+		}
+
+		checker.caFile = caFile
+		return nil
+	}
+}
+
+func DnsTimeout(timeout time.Duration) DnsOpts {
+	return func(checker *DnsChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+// DnsBinding sends queries from a source address, an interface or a network namespace.
+func DnsBinding(binding NetworkBinding) DnsOpts {
+	return func(checker *DnsChecker) error {
+		if err := binding.validate(); err != nil {
+			return err
+		}
+
+		checker.binding = binding
+		return nil
+	}
+}
+
+//nolint:cyclop
+func DnsCheckerFromMap(args map[string]any) (*DnsChecker, error) {
+	if args == nil {
+		return nil, errors.New("empty args supplied")
+	}
+
+	host, ok := args["host"]
+	if !ok {
+		return nil, errors.New("no 'host' supplied")
+	}
+
+	var opts []DnsOpts
+	servers, okServers, err := stringSliceFromArgs(args, "servers")
+	if err != nil {
+		return nil, err
+	}
+	server, okServer := args["server"].(string)
+	switch {
+	case okServers && okServer:
+		return nil, errors.New("'servers' and 'server' are mutually exclusive")
+	case okServers:
+		opts = append(opts, DnsServers(servers))
+	case okServer:
+		opts = append(opts, DnsServers([]string{server}))
+	}
+
+	if network, ok := args["network"].(string); ok {
+		opts = append(opts, DnsNetwork(network))
+	}
+
+	if recordType, ok := args["record_type"].(string); ok {
+		opts = append(opts, DnsRecordType(recordType))
+	}
+
+	expected, ok, err := stringSliceFromArgs(args, "expected")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, DnsExpectedAnswers(expected))
+	}
+
+	if noErrorOnly, ok := args["noerror_only"].(bool); ok {
+		opts = append(opts, DnsNoErrorOnly(noErrorOnly))
+	}
+
+	if serverName, ok := args["tls_server_name"].(string); ok {
+		opts = append(opts, DnsServerName(serverName))
+	}
+
+	if caFile, ok := args["tls_ca"].(string); ok {
+		opts = append(opts, DnsCaFile(caFile))
+	}
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, DnsTimeout(timeout))
+	}
+
+	if binding, ok := networkBindingFromArgs(args); ok {
+		opts = append(opts, DnsBinding(binding))
+	}
+
+	return NewDnsChecker(fmt.Sprintf("%s", host), opts...)
+}
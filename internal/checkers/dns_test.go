@@ -2,40 +2,480 @@ package checkers
 
 import (
 	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/miekg/dns"
 )
 
+func dnsTestHandler(w dns.ResponseWriter, req *dns.Msg) {
+	reply := new(dns.Msg)
+	reply.SetReply(req)
+
+	question := req.Question[0]
+	addRecord := func(record string) {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			panic(err)
+		}
+		reply.Answer = append(reply.Answer, rr)
+	}
+
+	switch question.Name {
+	case "example.com.":
+		switch question.Qtype {
+		case dns.TypeA:
+			addRecord("example.com. 300 IN A 192.0.2.1")
+			addRecord("example.com. 300 IN A 192.0.2.2")
+		case dns.TypeAAAA:
+			addRecord("example.com. 300 IN AAAA 2001:db8::1")
+		case dns.TypeMX:
+			addRecord("example.com. 300 IN MX 10 mx.example.com.")
+		case dns.TypeTXT:
+			addRecord(`example.com. 300 IN TXT "v=spf1 -all"`)
+		case dns.TypeSOA:
+			addRecord("example.com. 300 IN SOA ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 300")
+		}
+	case "nodata.example.com.":
+	case "servfail.example.com.":
+		reply.Rcode = dns.RcodeServerFailure
+	case "truncated.example.com.":
+		if _, isUdp := w.RemoteAddr().(*net.UDPAddr); isUdp {
+			reply.Truncated = true
+		} else {
+			addRecord("truncated.example.com. 300 IN A 192.0.2.3")
+		}
+	default:
+		reply.Rcode = dns.RcodeNameError
+	}
+
+	_ = w.WriteMsg(reply)
+}
+
+// startDnsServer starts an in-process DNS server listening on a local port for both udp and tcp.
+func startDnsServer(t *testing.T, handler dns.HandlerFunc) string {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen on udp: %v", err)
+	}
+	listener, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		_ = pc.Close()
+		t.Fatalf("could not listen on tcp: %v", err)
+	}
+
+	for _, server := range []*dns.Server{{PacketConn: pc, Handler: handler}, {Listener: listener, Handler: handler}} {
+		server := server
+		started := make(chan struct{})
+		server.NotifyStartedFunc = func() { close(started) }
+		go func() {
+			_ = server.ActivateAndServe()
+		}()
+		<-started
+		t.Cleanup(func() {
+			_ = server.Shutdown()
+		})
+	}
+
+	return pc.LocalAddr().String()
+}
+
 func TestDnsChecker_IsHealthy(t *testing.T) {
+	server := startDnsServer(t, dnsTestHandler)
 
 	tests := []struct {
-		name    string
-		host    string
-		want    bool
-		wantErr bool
+		name string
+		host string
+		opts []DnsOpts
+		want bool
+	}{
+		{
+			name: "a record",
+			host: "example.com",
+			want: true,
+		},
+		{
+			name: "a record via tcp",
+			host: "example.com",
+			opts: []DnsOpts{DnsNetwork(DnsNetworkTcp)},
+			want: true,
+		},
+		{
+			name: "a record from source ip",
+			host: "example.com",
+			opts: []DnsOpts{DnsBinding(NetworkBinding{SourceIp: "127.0.0.1"})},
+			want: true,
+		},
+		{
+			name: "expected a records",
+			host: "example.com",
+			opts: []DnsOpts{DnsExpectedAnswers([]string{"192.0.2.2", "192.0.2.1"})},
+			want: true,
+		},
+		{
+			name: "unexpected a records",
+			host: "example.com",
+			opts: []DnsOpts{DnsExpectedAnswers([]string{"192.0.2.1", "192.0.2.99"})},
+			want: false,
+		},
+		{
+			name: "aaaa record",
+			host: "example.com",
+			opts: []DnsOpts{DnsRecordType("AAAA"), DnsExpectedAnswers([]string{"2001:db8::1"})},
+			want: true,
+		},
+		{
+			name: "mx record",
+			host: "example.com",
+			opts: []DnsOpts{DnsRecordType("mx"), DnsExpectedAnswers([]string{"10 mx.example.com"})},
+			want: true,
+		},
+		{
+			name: "txt record",
+			host: "example.com",
+			opts: []DnsOpts{DnsRecordType("TXT"), DnsExpectedAnswers([]string{"v=spf1 -all"})},
+			want: true,
+		},
+		{
+			name: "soa record",
+			host: "example.com",
+			opts: []DnsOpts{DnsRecordType("SOA"), DnsExpectedAnswers([]string{"ns1.example.com."})},
+			want: true,
+		},
+		{
+			name: "no data",
+			host: "nodata.example.com",
+			want: false,
+		},
+		{
+			name: "no data, noerror only",
+			host: "nodata.example.com",
+			opts: []DnsOpts{DnsNoErrorOnly(true)},
+			want: true,
+		},
+		{
+			name: "nxdomain",
+			host: "nxdomain.example.com",
+			opts: []DnsOpts{DnsNoErrorOnly(true)},
+			want: false,
+		},
+		{
+			name: "servfail",
+			host: "servfail.example.com",
+			want: false,
+		},
+		{
+			name: "truncated reply retried via tcp",
+			host: "truncated.example.com",
+			opts: []DnsOpts{DnsExpectedAnswers([]string{"192.0.2.3"})},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]DnsOpts{DnsServers([]string{server})}, tt.opts...)
+			c, err := NewDnsChecker(tt.host, opts...)
+			if err != nil {
+				t.Fatalf("NewDnsChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
+
+func TestDnsChecker_IsHealthy_Tls(t *testing.T) {
+	certificate, caFile := generateTestCertificate(t, time.Now().Add(time.Hour), "dns.example.com", "127.0.0.1")
+	serverConfig := &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	server := &dns.Server{Listener: listener, Net: "tcp-tls", Handler: dns.HandlerFunc(dnsTestHandler)}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+
+	_, otherCaFile := generateTestCertificate(t, time.Now().Add(time.Hour), "other.example.com")
+
+	tests := []struct {
+		name string
+		opts []DnsOpts
+		want bool
 	}{
 		{
-			host:    "google.com",
-			want:    true,
-			wantErr: false,
+			name: "trusted",
+			opts: []DnsOpts{DnsCaFile(caFile)},
+			want: true,
+		},
+		{
+			name: "trusted with sni",
+			opts: []DnsOpts{DnsCaFile(caFile), DnsServerName("dns.example.com")},
+			want: true,
+		},
+		{
+			name: "sni mismatch",
+			opts: []DnsOpts{DnsCaFile(caFile), DnsServerName("wrong.example.com")},
+			want: false,
 		},
 		{
-			host:    "google.commmm",
-			want:    false,
-			wantErr: false,
+			name: "untrusted",
+			opts: []DnsOpts{DnsCaFile(otherCaFile)},
+			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &DnsChecker{
-				host: tt.host,
+			opts := append([]DnsOpts{DnsNetwork(DnsNetworkTls), DnsServers([]string{listener.Addr().String()}), DnsTimeout(time.Second)}, tt.opts...)
+			c, err := NewDnsChecker("example.com", opts...)
+			if err != nil {
+				t.Fatalf("NewDnsChecker() error = %v", err)
 			}
+
 			got, err := c.IsHealthy(context.Background())
-			if (err != nil) != tt.wantErr {
-				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
+
+// dohTestHandler answers DNS-over-HTTPS POST requests with the replies of dnsTestHandler.
+func dohTestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohContentType {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	req := new(dns.Msg)
+	if err := req.Unpack(body); err != nil || req.Id != 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	recorder := &dnsResponseRecorder{remote: &net.TCPAddr{}}
+	dnsTestHandler(recorder, req)
+	packed, err := recorder.msg.Pack()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", dohContentType)
+	_, _ = w.Write(packed)
+}
+
+type dnsResponseRecorder struct {
+	dns.ResponseWriter
+	remote net.Addr
+	msg    *dns.Msg
+}
+
+func (d *dnsResponseRecorder) RemoteAddr() net.Addr {
+	return d.remote
+}
+
+func (d *dnsResponseRecorder) WriteMsg(msg *dns.Msg) error {
+	d.msg = msg
+	return nil
+}
+
+func TestDnsChecker_IsHealthy_Https(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dns-query", dohTestHandler)
+	srv := httptest.NewUnstartedServer(mux)
+	certificate, caFile := generateTestCertificate(t, time.Now().Add(time.Hour), "127.0.0.1", "doh.example.com")
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	tests := []struct {
+		name   string
+		host   string
+		server string
+		opts   []DnsOpts
+		want   bool
+	}{
+		{
+			name:   "a record",
+			host:   "example.com",
+			server: srv.URL + "/dns-query",
+			opts:   []DnsOpts{DnsExpectedAnswers([]string{"192.0.2.1"})},
+			want:   true,
+		},
+		{
+			name:   "mx record with sni",
+			host:   "example.com",
+			server: srv.URL + "/dns-query",
+			opts:   []DnsOpts{DnsRecordType("MX"), DnsServerName("doh.example.com")},
+			want:   true,
+		},
+		{
+			name:   "nxdomain",
+			host:   "nxdomain.example.com",
+			server: srv.URL + "/dns-query",
+			want:   false,
+		},
+		{
+			name:   "wrong path",
+			host:   "example.com",
+			server: srv.URL + "/resolve",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]DnsOpts{DnsNetwork(DnsNetworkHttps), DnsServers([]string{tt.server}), DnsCaFile(caFile)}, tt.opts...)
+			c, err := NewDnsChecker(tt.host, opts...)
+			if err != nil {
+				t.Fatalf("NewDnsChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
 				return
 			}
 			if got != tt.want {
-				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
+
+func TestDnsChecker_IsHealthy_NoReply(t *testing.T) {
+	silent := startDnsServer(t, func(w dns.ResponseWriter, req *dns.Msg) {})
+	server := startDnsServer(t, dnsTestHandler)
+
+	c, err := NewDnsChecker("example.com", DnsServers([]string{silent}), DnsTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewDnsChecker() error = %v", err)
+	}
+
+	got, err := c.IsHealthy(context.Background())
+	if err != nil || got {
+		t.Errorf("IsHealthy() got = %v, err = %v, want false", got, err)
+	}
+
+	// the next server is queried if a server does not reply
+	c.servers = append(c.servers, server)
+	got, err = c.IsHealthy(context.Background())
+	if err != nil || !got {
+		t.Errorf("IsHealthy() got = %v, err = %v, want true (%s)", got, err, c.Reason())
+	}
+}
+
+func TestDnsChecker_getServers(t *testing.T) {
+	resolvConf := filepath.Join(t.TempDir(), "resolv.conf")
+	writeFixture(t, resolvConf, "search example.com\nnameserver 192.0.2.53\nnameserver 2001:db8::53\n")
+
+	c, err := NewDnsChecker("example.com")
+	if err != nil {
+		t.Fatalf("NewDnsChecker() error = %v", err)
+	}
+	c.resolvConf = resolvConf
+
+	got, err := c.getServers()
+	if err != nil {
+		t.Fatalf("getServers() error = %v", err)
+	}
+	want := []string{"192.0.2.53:53", "[2001:db8::53]:53"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getServers() got = %v, want %v", got, want)
+	}
+}
+
+func TestDnsCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "host only",
+			args: map[string]any{"host": "example.com"},
+		},
+		{
+			name: "all options",
+			args: map[string]any{
+				"host":        "example.com",
+				"servers":     []any{"192.0.2.53", "192.0.2.54:5353"},
+				"network":     "tcp",
+				"record_type": "MX",
+				"expected":    []any{"10 mx.example.com"},
+				"timeout":     "2s",
+			},
+		},
+		{
+			name:    "unknown record type",
+			args:    map[string]any{"host": "example.com", "record_type": "XYZ"},
+			wantErr: true,
+		},
+		{
+			name: "dns over tls",
+			args: map[string]any{"host": "example.com", "network": "tls", "server": "192.0.2.53", "tls_server_name": "dns.example.com"},
+		},
+		{
+			name:    "servers and server",
+			args:    map[string]any{"host": "example.com", "servers": []any{"192.0.2.53"}, "server": "192.0.2.54"},
+			wantErr: true,
+		},
+		{
+			name:    "dns over tls without server",
+			args:    map[string]any{"host": "example.com", "network": "tls"},
+			wantErr: true,
+		},
+		{
+			name:    "dns over https without url",
+			args:    map[string]any{"host": "example.com", "network": "https", "server": "192.0.2.53"},
+			wantErr: true,
+		},
+		{
+			name:    "server name for plain dns",
+			args:    map[string]any{"host": "example.com", "tls_server_name": "dns.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown network",
+			args:    map[string]any{"host": "example.com", "network": "sctp"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DnsCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DnsCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -0,0 +1,133 @@
+package checkers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	ExecCheckerName    = "exec"
+	execDefaultTimeout = 30 * time.Second
+	execWaitDelay      = 1 * time.Second
+
+	ExecResultHealthy   = "healthy"
+	ExecResultUnhealthy = "unhealthy"
+	ExecResultError     = "error"
+)
+
+// defaultExecExitCodes follows the exit codes of Nagios / monitoring-plugins: OK, WARNING, CRITICAL and UNKNOWN.
+var defaultExecExitCodes = map[int]string{
+	0: ExecResultHealthy,
+	1: ExecResultUnhealthy,
+	2: ExecResultUnhealthy,
+	3: ExecResultError,
+}
+
+// ExecChecker runs an arbitrary command and maps its exit code to a result. The first line of its output is used as
+// the reason, which makes it compatible with Nagios / monitoring-plugins.
+type ExecChecker struct {
+	command   string
+	args      []string
+	env       map[string]string
+	workDir   string
+	timeout   time.Duration
+	exitCodes map[int]string
+
+	mutex  sync.Mutex
+	output string
+}
+
+type ExecOpts func(checker *ExecChecker) error
+
+func NewExecChecker(command string, opts ...ExecOpts) (*ExecChecker, error) {
+	if len(command) == 0 {
+		return nil, errors.New("empty 'command' provided")
+	}
+
+	checker := &ExecChecker{
+		command:   command,
+		timeout:   execDefaultTimeout,
+		exitCodes: defaultExecExitCodes,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return checker, errs
+}
+
+func (c *ExecChecker) Name() string {
+	return fmt.Sprintf("%s://%s", ExecCheckerName, c.command)
+}
+
+func (c *ExecChecker) IsHealthy(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// #nosec G204
+	cmd := exec.CommandContext(ctx, c.command, c.args...)
+	cmd.Dir = c.workDir
+	cmd.Env = os.Environ()
+	for key, val := range c.env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, val))
+	}
+	// do not wait forever for orphaned child processes that keep stdout open
+	cmd.WaitDelay = execWaitDelay
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if ctx.Err() != nil || !errors.As(err, &exitErr) {
+			return false, fmt.Errorf("could not run '%s': %w", c.command, err)
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	output := parseExecOutput(stdout.String())
+	c.mutex.Lock()
+	c.output = output
+	c.mutex.Unlock()
+
+	switch c.exitCodes[exitCode] {
+	case ExecResultHealthy:
+		return true, nil
+	case ExecResultUnhealthy:
+		log.Warn().Str("checker", "exec").Int("exit_code", exitCode).Msgf("Checker '%s' reported: %s", c.Name(), output)
+		return false, nil
+	case ExecResultError:
+		return false, fmt.Errorf("'%s' exited with code %d: %s", c.command, exitCode, output)
+	}
+
+	return false, fmt.Errorf("'%s' exited with unmapped code %d: %s", c.command, exitCode, output)
+}
+
+func (c *ExecChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.output
+}
+
+// parseExecOutput returns the first line of the output without the performance data of monitoring plugins.
+func parseExecOutput(out string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	line, _, _ = strings.Cut(line, "|")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,125 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func ExecArgs(args []string) ExecOpts {
+	return func(checker *ExecChecker) error {
+		checker.args = args
+		return nil
+	}
+}
+
+// ExecEnv sets additional environment variables, the environment of conditional-reboot is inherited.
+func ExecEnv(env map[string]string) ExecOpts {
+	return func(checker *ExecChecker) error {
+		checker.env = env
+		return nil
+	}
+}
+
+func ExecWorkDir(dir string) ExecOpts {
+	return func(checker *ExecChecker) error {
+		if len(dir) == 0 {
+			return errors.New("empty work dir provided")
+		}
+
+		checker.workDir = dir
+		return nil
+	}
+}
+
+func ExecTimeout(timeout time.Duration) ExecOpts {
+	return func(checker *ExecChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be > 0")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+// ExecExitCodes replaces the default mapping of exit codes to results. Exit codes that are not mapped yield an error.
+func ExecExitCodes(exitCodes map[int]string) ExecOpts {
+	return func(checker *ExecChecker) error {
+		if len(exitCodes) == 0 {
+			return errors.New("empty exit code mapping provided")
+		}
+
+		for code, result := range exitCodes {
+			switch result {
+			case ExecResultHealthy, ExecResultUnhealthy, ExecResultError:
+			default:
+				return fmt.Errorf("invalid result '%s' for exit code %d", result, code)
+			}
+		}
+
+		checker.exitCodes = exitCodes
+		return nil
+	}
+}
+
+//nolint:cyclop
+func ExecCheckerFromMap(args map[string]any) (*ExecChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build exec checker, empty args supplied")
+	}
+
+	command, ok := args["command"].(string)
+	if !ok {
+		return nil, errors.New("could not build exec checker, no 'command' supplied")
+	}
+
+	var opts []ExecOpts
+	cmdArgs, ok, err := stringSliceFromArgs(args, "args")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, ExecArgs(cmdArgs))
+	}
+
+	env, ok, err := stringMapFromArgs(args, "env")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, ExecEnv(env))
+	}
+
+	if workDir, ok := args["work_dir"].(string); ok {
+		opts = append(opts, ExecWorkDir(workDir))
+	}
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, ExecTimeout(timeout))
+	}
+
+	exitCodesRaw, ok, err := stringMapFromArgs(args, "exit_codes")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		exitCodes := map[int]string{}
+		for codeRaw, result := range exitCodesRaw {
+			code, err := strconv.Atoi(codeRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exit code '%s'", codeRaw)
+			}
+			exitCodes[code] = strings.ToLower(result)
+		}
+		opts = append(opts, ExecExitCodes(exitCodes))
+	}
+
+	return NewExecChecker(command, opts...)
+}
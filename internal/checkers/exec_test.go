@@ -0,0 +1,142 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExecChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     string
+		opts       []ExecOpts
+		want       bool
+		wantErr    bool
+		wantReason string
+	}{
+		{
+			name:       "ok",
+			script:     "echo 'OK - all good | load=0.1'; exit 0",
+			want:       true,
+			wantReason: "OK - all good",
+		},
+		{
+			name:       "warning",
+			script:     "echo 'WARNING - load high'; exit 1",
+			want:       false,
+			wantReason: "WARNING - load high",
+		},
+		{
+			name:       "critical",
+			script:     "echo 'CRITICAL - nic gone'; echo 'long output'; exit 2",
+			want:       false,
+			wantReason: "CRITICAL - nic gone",
+		},
+		{
+			name:    "unknown",
+			script:  "echo 'UNKNOWN - no idea'; exit 3",
+			wantErr: true,
+		},
+		{
+			name:    "unmapped exit code",
+			script:  "exit 42",
+			wantErr: true,
+		},
+		{
+			name:   "custom mapping",
+			script: "exit 1",
+			opts:   []ExecOpts{ExecExitCodes(map[int]string{0: ExecResultHealthy, 1: ExecResultHealthy, 2: ExecResultUnhealthy})},
+			want:   true,
+		},
+		{
+			name:       "env",
+			script:     "echo \"$GREETING\"; test \"$GREETING\" = hello",
+			opts:       []ExecOpts{ExecEnv(map[string]string{"GREETING": "hello"})},
+			want:       true,
+			wantReason: "hello",
+		},
+		{
+			name:       "work dir",
+			script:     "pwd",
+			opts:       []ExecOpts{ExecWorkDir("/")},
+			want:       true,
+			wantReason: "/",
+		},
+		{
+			name:    "timeout",
+			script:  "sleep 5",
+			opts:    []ExecOpts{ExecTimeout(100 * time.Millisecond)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ExecOpts{ExecArgs([]string{"-c", tt.script})}, tt.opts...)
+			c, err := NewExecChecker("sh", opts...)
+			if err != nil {
+				t.Fatalf("NewExecChecker() error = %v", err)
+			}
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if len(tt.wantReason) > 0 && c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestExecCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "no command",
+			args:    map[string]any{"args": []any{"-c", "true"}},
+			wantErr: true,
+		},
+		{
+			name: "full",
+			args: map[string]any{
+				"command":    "/usr/lib/nagios/plugins/check_disk",
+				"args":       []any{"-w", 10, "-c", 5},
+				"env":        map[string]any{"LANG": "C"},
+				"work_dir":   "/tmp",
+				"timeout":    "10s",
+				"exit_codes": map[any]any{0: "healthy", 1: "healthy", 2: "unhealthy", 3: "error"},
+			},
+		},
+		{
+			name: "invalid result",
+			args: map[string]any{
+				"command":    "true",
+				"exit_codes": map[string]any{"0": "fine"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid exit code",
+			args: map[string]any{
+				"command":    "true",
+				"exit_codes": map[string]any{"zero": "healthy"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExecCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExecCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
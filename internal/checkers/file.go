@@ -32,7 +32,17 @@ func FileCheckerFromMap(args map[string]any) (*FileChecker, error) {
 		return nil, errors.New("no 'file' supplied")
 	}
 
-	return NewFileChecker(fmt.Sprintf("%s", file))
+	checker, err := NewFileChecker(fmt.Sprintf("%s", file))
+	if err != nil {
+		return nil, err
+	}
+
+	wantsAbsence, ok := args["wants_absence"].(bool)
+	if ok {
+		checker.wantsAbsence = wantsAbsence
+	}
+
+	return checker, nil
 }
 
 func (c *FileChecker) Name() string {
@@ -0,0 +1,258 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	FilesystemCheckerName         = "filesystem"
+	defaultFilesystemProbeTimeout = 10 * time.Second
+	filesystemProbeFile           = ".conditional-reboot-probe"
+)
+
+type mountInfo struct {
+	mountPoint   string
+	mountOptions []string
+	fsType       string
+	source       string
+	superOptions []string
+}
+
+func (m mountInfo) isReadOnly() bool {
+	for _, opts := range [][]string{m.mountOptions, m.superOptions} {
+		for _, opt := range opts {
+			if opt == "ro" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FilesystemChecker reports an unhealthy state if configured mountpoints have been (re-)mounted read-only, e.g. due
+// to 'errors=remount-ro', or if writing a probe file into configured directories fails or hangs, which happens with
+// stale NFS mounts.
+type FilesystemChecker struct {
+	mountPoints  []string
+	probeDirs    []string
+	probeTimeout time.Duration
+	procRoot     string
+	probe        func(dir string) error
+
+	mutex         sync.Mutex
+	pendingProbes map[string]bool
+	problems      []string
+}
+
+type FilesystemOpts func(checker *FilesystemChecker) error
+
+func NewFilesystemChecker(opts ...FilesystemOpts) (*FilesystemChecker, error) {
+	checker := &FilesystemChecker{
+		probeTimeout:  defaultFilesystemProbeTimeout,
+		procRoot:      defaultProcRoot,
+		probe:         writeProbeFile,
+		pendingProbes: map[string]bool{},
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if len(checker.mountPoints) == 0 && len(checker.probeDirs) == 0 {
+		errs = multierr.Append(errs, errors.New("neither mountpoints nor probe dirs provided"))
+	}
+
+	return checker, errs
+}
+
+func (c *FilesystemChecker) Name() string {
+	return fmt.Sprintf("%s://%s", FilesystemCheckerName, strings.Join(append(append([]string{}, c.mountPoints...), c.probeDirs...), ","))
+}
+
+func (c *FilesystemChecker) IsHealthy(ctx context.Context) (bool, error) {
+	var problems []string
+
+	if len(c.mountPoints) > 0 {
+		mounts, err := readMountInfo(filepath.Join(c.procRoot, "self", "mountinfo"))
+		if err != nil {
+			return false, err
+		}
+
+		for _, mountPoint := range c.mountPoints {
+			mount, found := mounts[filepath.Clean(mountPoint)]
+			if !found {
+				problems = append(problems, fmt.Sprintf("%s: not mounted", mountPoint))
+			} else if mount.isReadOnly() {
+				problems = append(problems, fmt.Sprintf("%s: mounted read-only", mountPoint))
+			}
+		}
+	}
+
+	for _, dir := range c.probeDirs {
+		if err := c.probeDir(ctx, dir); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", dir, err))
+		}
+	}
+
+	c.mutex.Lock()
+	c.problems = problems
+	c.mutex.Unlock()
+
+	if len(problems) > 0 {
+		log.Warn().Str("checker", "filesystem").Strs("problems", problems).Msgf("Checker '%s' detected problems", c.Name())
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (c *FilesystemChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return strings.Join(c.problems, ", ")
+}
+
+// probeDir writes a probe file in the background. Writes to stale network mounts can block forever, so a new probe
+// is only started once the previous one for the same directory has returned.
+func (c *FilesystemChecker) probeDir(ctx context.Context, dir string) error {
+	c.mutex.Lock()
+	if c.pendingProbes[dir] {
+		c.mutex.Unlock()
+		return errors.New("previous probe still hanging")
+	}
+	c.pendingProbes[dir] = true
+	c.mutex.Unlock()
+
+	result := make(chan error, 1)
+	go func() {
+		err := c.probe(dir)
+		c.mutex.Lock()
+		delete(c.pendingProbes, dir)
+		c.mutex.Unlock()
+		result <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, c.probeTimeout)
+	defer cancel()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return fmt.Errorf("probe failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("probe did not finish within %s", c.probeTimeout)
+	}
+}
+
+func writeProbeFile(dir string) error {
+	file := filepath.Join(dir, filesystemProbeFile)
+	// #nosec G304
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	_, writeErr := f.WriteString(time.Now().Format(time.RFC3339))
+	var errs error
+	if writeErr != nil {
+		errs = multierr.Append(errs, writeErr)
+	}
+	if err := f.Sync(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if err := f.Close(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if err := os.Remove(file); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	return errs
+}
+
+func readMountInfo(file string) (map[string]mountInfo, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read mountinfo: %w", err)
+	}
+
+	return parseMountInfo(data), nil
+}
+
+// parseMountInfo parses the format of /proc/<pid>/mountinfo, see proc(5). If multiple filesystems are mounted on the
+// same mountpoint, the last one, which is the visible one, is returned.
+func parseMountInfo(data []byte) map[string]mountInfo {
+	mounts := map[string]mountInfo{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		// optional fields are terminated by a single hyphen
+		separator := -1
+		for idx := 6; idx < len(fields); idx++ {
+			if fields[idx] == "-" {
+				separator = idx
+				break
+			}
+		}
+		if separator < 0 || len(fields) < separator+4 {
+			continue
+		}
+
+		mount := mountInfo{
+			mountPoint:   unescapeMountInfo(fields[4]),
+			mountOptions: strings.Split(fields[5], ","),
+			fsType:       fields[separator+1],
+			source:       unescapeMountInfo(fields[separator+2]),
+			superOptions: strings.Split(fields[separator+3], ","),
+		}
+		mounts[mount.mountPoint] = mount
+	}
+
+	return mounts
+}
+
+// unescapeMountInfo decodes octal escapes, e.g. '\040' for spaces.
+func unescapeMountInfo(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+
+	var sb strings.Builder
+	for idx := 0; idx < len(field); idx++ {
+		if field[idx] == '\\' && idx+3 < len(field) {
+			if val, err := strconv.ParseUint(field[idx+1:idx+4], 8, 8); err == nil {
+				sb.WriteByte(byte(val))
+				idx += 3
+				continue
+			}
+		}
+		sb.WriteByte(field[idx])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,85 @@
+package checkers
+
+import (
+	"errors"
+	"time"
+)
+
+func FilesystemMountPoints(mountPoints []string) FilesystemOpts {
+	return func(checker *FilesystemChecker) error {
+		if len(mountPoints) == 0 {
+			return errors.New("no mountpoints provided")
+		}
+
+		checker.mountPoints = mountPoints
+		return nil
+	}
+}
+
+// FilesystemProbeDirs sets the directories a probe file is written to on each check.
+func FilesystemProbeDirs(dirs []string) FilesystemOpts {
+	return func(checker *FilesystemChecker) error {
+		if len(dirs) == 0 {
+			return errors.New("no probe dirs provided")
+		}
+
+		checker.probeDirs = dirs
+		return nil
+	}
+}
+
+func FilesystemProbeTimeout(timeout time.Duration) FilesystemOpts {
+	return func(checker *FilesystemChecker) error {
+		if timeout <= 0 {
+			return errors.New("probe timeout must be positive")
+		}
+
+		checker.probeTimeout = timeout
+		return nil
+	}
+}
+
+func FilesystemProcRoot(root string) FilesystemOpts {
+	return func(checker *FilesystemChecker) error {
+		if len(root) == 0 {
+			return errors.New("empty proc root provided")
+		}
+
+		checker.procRoot = root
+		return nil
+	}
+}
+
+func FilesystemCheckerFromMap(args map[string]any) (*FilesystemChecker, error) {
+	var opts []FilesystemOpts
+
+	mountPoints, ok, err := stringSliceFromArgs(args, "mountpoints")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, FilesystemMountPoints(mountPoints))
+	}
+
+	probeDirs, ok, err := stringSliceFromArgs(args, "probe_dirs")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, FilesystemProbeDirs(probeDirs))
+	}
+
+	timeout, ok, err := durationFromArgs(args, "probe_timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, FilesystemProbeTimeout(timeout))
+	}
+
+	if root, ok := args["proc_root"].(string); ok {
+		opts = append(opts, FilesystemProcRoot(root))
+	}
+
+	return NewFilesystemChecker(opts...)
+}
@@ -0,0 +1,141 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const procSelfMountInfo = `22 28 0:21 / /proc rw,nosuid,nodev,noexec,relatime shared:13 - proc proc rw
+28 1 253:0 / / rw,relatime shared:1 - ext4 /dev/mapper/vg-root rw,errors=remount-ro
+45 28 253:1 / /var/lib/data ro,relatime shared:29 - ext4 /dev/mapper/vg-data rw,errors=remount-ro
+46 28 253:2 / /srv rw,relatime shared:30 - xfs /dev/mapper/vg-srv ro,attr2
+47 28 0:45 / /mnt/my\040share rw,relatime shared:31 - nfs4 nas:/export/my\040share rw,vers=4.2
+`
+
+func Test_parseMountInfo(t *testing.T) {
+	mounts := parseMountInfo([]byte(procSelfMountInfo))
+
+	want := map[string]bool{
+		"/proc":         false,
+		"/":             false,
+		"/var/lib/data": true,
+		"/srv":          true,
+		"/mnt/my share": false,
+	}
+
+	got := map[string]bool{}
+	for mountPoint, mount := range mounts {
+		got[mountPoint] = mount.isReadOnly()
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMountInfo() = %v, want %v", got, want)
+	}
+
+	if mounts["/mnt/my share"].source != "nas:/export/my share" {
+		t.Errorf("parseMountInfo() source = %q", mounts["/mnt/my share"].source)
+	}
+}
+
+func TestFilesystemChecker_IsHealthy_MountPoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		mountPoints []string
+		want        bool
+		wantReason  string
+	}{
+		{
+			name:        "rw",
+			mountPoints: []string{"/", "/mnt/my share"},
+			want:        true,
+		},
+		{
+			name:        "remounted ro",
+			mountPoints: []string{"/", "/var/lib/data"},
+			want:        false,
+			wantReason:  "/var/lib/data: mounted read-only",
+		},
+		{
+			name:        "superblock ro",
+			mountPoints: []string{"/srv/"},
+			want:        false,
+			wantReason:  "/srv/: mounted read-only",
+		},
+		{
+			name:        "not mounted",
+			mountPoints: []string{"/home"},
+			want:        false,
+			wantReason:  "/home: not mounted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFixture(t, filepath.Join(root, "self", "mountinfo"), procSelfMountInfo)
+
+			c, err := NewFilesystemChecker(FilesystemProcRoot(root), FilesystemMountPoints(tt.mountPoints))
+			if err != nil {
+				t.Fatalf("NewFilesystemChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Fatalf("IsHealthy() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestFilesystemChecker_IsHealthy_Probe(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewFilesystemChecker(FilesystemProbeDirs([]string{dir, filepath.Join(dir, "missing")}))
+	if err != nil {
+		t.Fatalf("NewFilesystemChecker() error = %v", err)
+	}
+
+	got, err := c.IsHealthy(context.Background())
+	if err != nil || got {
+		t.Errorf("IsHealthy() got = %v, err = %v, want false", got, err)
+	}
+
+	c.probeDirs = []string{dir}
+	got, err = c.IsHealthy(context.Background())
+	if err != nil || !got {
+		t.Errorf("IsHealthy() got = %v, err = %v, want true (%s)", got, err, c.Reason())
+	}
+}
+
+func TestFilesystemChecker_IsHealthy_HangingProbe(t *testing.T) {
+	c, err := NewFilesystemChecker(FilesystemProbeDirs([]string{"/mnt/nfs"}), FilesystemProbeTimeout(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewFilesystemChecker() error = %v", err)
+	}
+
+	release := make(chan struct{})
+	c.probe = func(dir string) error {
+		<-release
+		return errors.New("stale file handle")
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := c.IsHealthy(context.Background())
+		if err != nil || got {
+			t.Errorf("IsHealthy() #%d got = %v, err = %v, want false", i, got, err)
+		}
+	}
+
+	if c.Reason() != "/mnt/nfs: previous probe still hanging" {
+		t.Errorf("Reason() got = %v", c.Reason())
+	}
+
+	close(release)
+}
@@ -0,0 +1,274 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	GatewayCheckerName = "gateway"
+	defaultProcRoot    = "/proc"
+
+	rtfUp      = 0x1
+	rtfGateway = 0x2
+	atfCom     = 0x2
+)
+
+// hostByteOrder is the byte order /proc/net/route encodes addresses in.
+var hostByteOrder = func() binary.ByteOrder {
+	probe := uint16(0x0102)
+	// #nosec G103
+	if *(*byte)(unsafe.Pointer(&probe)) == 0x01 {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}()
+
+type gateway struct {
+	ip    net.IP
+	iface string
+}
+
+// Addr returns the address of the gateway. Link-local addresses are scoped to the interface of the route.
+func (g gateway) Addr() string {
+	if g.ip.IsLinkLocalUnicast() && len(g.iface) > 0 {
+		return fmt.Sprintf("%s%%%s", g.ip, g.iface)
+	}
+	return g.ip.String()
+}
+
+// GatewayProbe checks whether a gateway is reachable.
+type GatewayProbe func(ctx context.Context, gw string) (bool, error)
+
+// GatewayChecker detects the default gateways by parsing the kernel's routing tables and checks whether at least one
+// of them replies to ICMP echo requests. Optionally, IPv4 gateways that do not reply to ICMP are considered reachable
+// if their ARP entry is complete, see GatewayArpFallback.
+type GatewayChecker struct {
+	procRoot    string
+	ipv4        bool
+	ipv6        bool
+	arpFallback bool
+	privileged  bool
+	probe       GatewayProbe
+
+	mutex  sync.Mutex
+	reason string
+}
+
+type GatewayOpts func(checker *GatewayChecker) error
+
+func NewGatewayChecker(opts ...GatewayOpts) (*GatewayChecker, error) {
+	checker := &GatewayChecker{
+		procRoot:   defaultProcRoot,
+		ipv4:       true,
+		ipv6:       true,
+		privileged: getPrivilegedDefaultForPlatform(),
+	}
+	checker.probe = checker.icmpProbe
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return checker, errs
+}
+
+func (c *GatewayChecker) Name() string {
+	return GatewayCheckerName
+}
+
+func (c *GatewayChecker) icmpProbe(ctx context.Context, gw string) (bool, error) {
+	icmp, err := NewIcmpChecker(gw, IcmpPrivileged(c.privileged))
+	if err != nil {
+		return false, err
+	}
+
+	return icmp.IsHealthy(ctx)
+}
+
+//nolint:cyclop
+func (c *GatewayChecker) IsHealthy(ctx context.Context) (bool, error) {
+	gateways, err := c.defaultGateways()
+	if err != nil {
+		return false, err
+	}
+
+	if len(gateways) == 0 {
+		c.setReason("no default gateway found")
+		log.Warn().Str("checker", "gateway").Msg("No default gateway found")
+		return false, nil
+	}
+
+	var errs error
+	for _, gw := range gateways {
+		reachable, err := c.probe(ctx, gw.Addr())
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("could not probe gateway %s: %w", gw.Addr(), err))
+		}
+
+		if !reachable && c.arpFallback && gw.ip.To4() != nil {
+			reachable, err = c.hasCompleteArpEntry(gw)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+			}
+		}
+
+		if reachable {
+			log.Debug().Str("checker", "gateway").Msgf("Gateway %s is reachable", gw.Addr())
+			return true, nil
+		}
+	}
+
+	if errs != nil {
+		log.Warn().Str("checker", "gateway").Err(errs).Msg("Errors while probing gateways")
+	}
+
+	var addrs []string
+	for _, gw := range gateways {
+		addrs = append(addrs, gw.Addr())
+	}
+	c.setReason(fmt.Sprintf("gateways %s unreachable", strings.Join(addrs, ", ")))
+	log.Warn().Str("checker", "gateway").Strs("gateways", addrs).Msg("No gateway reachable")
+	return false, nil
+}
+
+func (c *GatewayChecker) setReason(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reason = reason
+}
+
+func (c *GatewayChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
+
+func (c *GatewayChecker) defaultGateways() ([]gateway, error) {
+	var gateways []gateway
+
+	if c.ipv4 {
+		data, err := os.ReadFile(filepath.Join(c.procRoot, "net", "route"))
+		if err != nil {
+			return nil, fmt.Errorf("could not read ipv4 routes: %w", err)
+		}
+		gateways = append(gateways, parseIpv4DefaultGateways(data, hostByteOrder)...)
+	}
+
+	if c.ipv6 {
+		data, err := os.ReadFile(filepath.Join(c.procRoot, "net", "ipv6_route"))
+		// the file does not exist if ipv6 is disabled
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("could not read ipv6 routes: %w", err)
+		}
+		gateways = append(gateways, parseIpv6DefaultGateways(data)...)
+	}
+
+	return gateways, nil
+}
+
+func (c *GatewayChecker) hasCompleteArpEntry(gw gateway) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(c.procRoot, "net", "arp"))
+	if err != nil {
+		return false, fmt.Errorf("could not read arp table: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || !gw.ip.Equal(net.ParseIP(fields[0])) {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 32)
+		if err != nil {
+			continue
+		}
+
+		if flags&atfCom != 0 && (len(gw.iface) == 0 || fields[5] == gw.iface) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// parseIpv4DefaultGateways parses the contents of /proc/net/route, addresses are hex-encoded in host byte order.
+func parseIpv4DefaultGateways(data []byte, byteOrder binary.ByteOrder) []gateway {
+	var gateways []gateway
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil || flags&(rtfUp|rtfGateway) != rtfUp|rtfGateway {
+			continue
+		}
+
+		addr, err := strconv.ParseUint(fields[2], 16, 32)
+		if err != nil {
+			continue
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		byteOrder.PutUint32(ip, uint32(addr))
+		gateways = append(gateways, gateway{
+			ip:    ip,
+			iface: fields[0],
+		})
+	}
+
+	return gateways
+}
+
+// parseIpv6DefaultGateways parses the contents of /proc/net/ipv6_route.
+func parseIpv6DefaultGateways(data []byte) []gateway {
+	var gateways []gateway
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[1] != "00" || strings.Trim(fields[0], "0") != "" {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(fields[8], 16, 32)
+		if err != nil || flags&(rtfUp|rtfGateway) != rtfUp|rtfGateway {
+			continue
+		}
+
+		raw, err := hex.DecodeString(fields[4])
+		if err != nil || len(raw) != net.IPv6len {
+			continue
+		}
+
+		gateways = append(gateways, gateway{
+			ip:    net.IP(raw),
+			iface: fields[9],
+		})
+	}
+
+	return gateways
+}
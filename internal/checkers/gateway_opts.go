@@ -0,0 +1,73 @@
+package checkers
+
+import "errors"
+
+func GatewayProcRoot(root string) GatewayOpts {
+	return func(checker *GatewayChecker) error {
+		if len(root) == 0 {
+			return errors.New("empty proc root provided")
+		}
+
+		checker.procRoot = root
+		return nil
+	}
+}
+
+func GatewayAddressFamilies(ipv4, ipv6 bool) GatewayOpts {
+	return func(checker *GatewayChecker) error {
+		if !ipv4 && !ipv6 {
+			return errors.New("at least one address family needs to be enabled")
+		}
+
+		checker.ipv4 = ipv4
+		checker.ipv6 = ipv6
+		return nil
+	}
+}
+
+// GatewayArpFallback toggles whether an IPv4 gateway that does not reply to ICMP is considered reachable if its ARP
+// entry is complete. This helps with gateways that drop ICMP, but the kernel keeps entries complete while they are
+// stale, so a gateway that died recently is still considered reachable until its entry is garbage collected. It's
+// disabled by default.
+func GatewayArpFallback(arpFallback bool) GatewayOpts {
+	return func(checker *GatewayChecker) error {
+		checker.arpFallback = arpFallback
+		return nil
+	}
+}
+
+func GatewayPrivileged(privileged bool) GatewayOpts {
+	return func(checker *GatewayChecker) error {
+		checker.privileged = privileged
+		return nil
+	}
+}
+
+func GatewayCheckerFromMap(args map[string]any) (*GatewayChecker, error) {
+	var opts []GatewayOpts
+	if root, ok := args["proc_root"].(string); ok {
+		opts = append(opts, GatewayProcRoot(root))
+	}
+
+	ipv4, okIpv4 := args["ipv4"].(bool)
+	ipv6, okIpv6 := args["ipv6"].(bool)
+	if okIpv4 || okIpv6 {
+		if !okIpv4 {
+			ipv4 = true
+		}
+		if !okIpv6 {
+			ipv6 = true
+		}
+		opts = append(opts, GatewayAddressFamilies(ipv4, ipv6))
+	}
+
+	if arpFallback, ok := args["arp_fallback"].(bool); ok {
+		opts = append(opts, GatewayArpFallback(arpFallback))
+	}
+
+	if privileged, ok := args["privileged"].(bool); ok {
+		opts = append(opts, GatewayPrivileged(privileged))
+	}
+
+	return NewGatewayChecker(opts...)
+}
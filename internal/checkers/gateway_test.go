@@ -0,0 +1,163 @@
+package checkers
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	procNetRoute = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	00000000	0102A8C0	0003	0	0	100	00000000	0	0	0
+eth0	0002A8C0	00000000	0001	0	0	100	00FFFFFF	0	0	0
+wg0	0000000A	00000000	0001	0	0	0	000000FF	0	0	0
+`
+	// as written by big-endian hosts, such as MIPS or PowerPC based routers
+	procNetRouteBigEndian = `Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+eth0	00000000	C0A80201	0003	0	0	100	00000000	0	0	0
+eth0	C0A80200	00000000	0001	0	0	100	FFFFFF00	0	0	0
+`
+	procNetIpv6Route = `fd000000000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe80000000000000022186fffe2c1a2b 00000400 00000001 00000000 00000003     eth0
+00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo
+`
+	procNetArp = `IP address       HW type     Flags       HW address            Mask     Device
+192.168.2.1      0x1         0x2         aa:bb:cc:dd:ee:ff     *        eth0
+192.168.2.20     0x1         0x0         00:00:00:00:00:00     *        eth0
+`
+)
+
+func Test_parseIpv4DefaultGateways(t *testing.T) {
+	tests := []struct {
+		name      string
+		route     string
+		byteOrder binary.ByteOrder
+	}{
+		{
+			name:      "little endian",
+			route:     procNetRoute,
+			byteOrder: binary.LittleEndian,
+		},
+		{
+			name:      "big endian",
+			route:     procNetRouteBigEndian,
+			byteOrder: binary.BigEndian,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseIpv4DefaultGateways([]byte(tt.route), tt.byteOrder)
+			want := []string{"192.168.2.1"}
+
+			var addrs []string
+			for _, gw := range got {
+				addrs = append(addrs, gw.Addr())
+			}
+			if !reflect.DeepEqual(addrs, want) {
+				t.Errorf("parseIpv4DefaultGateways() = %v, want %v", addrs, want)
+			}
+		})
+	}
+}
+
+func Test_parseIpv6DefaultGateways(t *testing.T) {
+	got := parseIpv6DefaultGateways([]byte(procNetIpv6Route))
+	want := []string{"fe80::221:86ff:fe2c:1a2b%eth0"}
+
+	var addrs []string
+	for _, gw := range got {
+		addrs = append(addrs, gw.Addr())
+	}
+	if !reflect.DeepEqual(addrs, want) {
+		t.Errorf("parseIpv6DefaultGateways() = %v, want %v", addrs, want)
+	}
+}
+
+type gatewayProbeDummy struct {
+	reachable map[string]bool
+}
+
+func (g *gatewayProbeDummy) probe(_ context.Context, gw string) (bool, error) {
+	reachable, ok := g.reachable[gw]
+	if !ok {
+		return false, errors.New("unknown host")
+	}
+	return reachable, nil
+}
+
+func TestGatewayChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name      string
+		route     string
+		ipv6Route string
+		arp       string
+		opts      []GatewayOpts
+		reachable map[string]bool
+		want      bool
+	}{
+		{
+			name:      "ipv4 gateway reachable",
+			route:     procNetRoute,
+			reachable: map[string]bool{"192.168.2.1": true},
+			want:      true,
+		},
+		{
+			name:      "ipv4 gateway unreachable, ipv6 gateway reachable",
+			route:     procNetRoute,
+			ipv6Route: procNetIpv6Route,
+			reachable: map[string]bool{"192.168.2.1": false, "fe80::221:86ff:fe2c:1a2b%eth0": true},
+			want:      true,
+		},
+		{
+			name:      "ipv4 gateway drops icmp, arp entry complete",
+			route:     procNetRoute,
+			arp:       procNetArp,
+			opts:      []GatewayOpts{GatewayArpFallback(true)},
+			reachable: map[string]bool{"192.168.2.1": false},
+			want:      true,
+		},
+		{
+			name:      "ipv4 gateway drops icmp, arp fallback disabled by default",
+			route:     procNetRoute,
+			arp:       procNetArp,
+			reachable: map[string]bool{"192.168.2.1": false},
+			want:      false,
+		},
+		{
+			name:      "no default gateway",
+			route:     "Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT\n",
+			reachable: map[string]bool{},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFixture(t, filepath.Join(root, "net", "route"), tt.route)
+			if len(tt.ipv6Route) > 0 {
+				writeFixture(t, filepath.Join(root, "net", "ipv6_route"), tt.ipv6Route)
+			}
+			writeFixture(t, filepath.Join(root, "net", "arp"), tt.arp)
+
+			opts := append([]GatewayOpts{GatewayProcRoot(root)}, tt.opts...)
+			c, err := NewGatewayChecker(opts...)
+			if err != nil {
+				t.Fatalf("NewGatewayChecker() error = %v", err)
+			}
+			dummy := &gatewayProbeDummy{reachable: tt.reachable}
+			c.probe = dummy.probe
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package checkers
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// hostTemplateData is passed to templates in checker arguments, so a single config can be shared by many hosts, e.g.
+// 'up{instance="{{ .Hostname }}"}'.
+type hostTemplateData struct {
+	Hostname string
+	Env      map[string]string
+}
+
+func newHostTemplateData() (hostTemplateData, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return hostTemplateData{}, fmt.Errorf("could not determine hostname: %w", err)
+	}
+
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		key, val, _ := strings.Cut(kv, "=")
+		env[key] = val
+	}
+
+	return hostTemplateData{
+		Hostname: hostname,
+		Env:      env,
+	}, nil
+}
+
+// FQDN returns the fully qualified domain name of the host by resolving its hostname. It is only evaluated if a
+// template refers to it and falls back to the hostname if the lookup fails.
+func (d hostTemplateData) FQDN() string {
+	addrs, err := net.LookupHost(d.Hostname)
+	if err != nil {
+		return d.Hostname
+	}
+
+	for _, addr := range addrs {
+		names, err := net.LookupAddr(addr)
+		if err == nil && len(names) > 0 {
+			return strings.TrimSuffix(names[0], ".")
+		}
+	}
+
+	return d.Hostname
+}
+
+// renderHostTemplate renders text as template with information about the host. Referring to undefined environment
+// variables is an error.
+func renderHostTemplate(text string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	data, err := newHostTemplateData()
+	if err != nil {
+		return "", err
+	}
+
+	return renderTemplate(text, data)
+}
+
+func renderTemplate(text string, data hostTemplateData) (string, error) {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("could not render template: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,74 @@
+package checkers
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_renderTemplate(t *testing.T) {
+	data := hostTemplateData{
+		Hostname: "node-1",
+		Env:      map[string]string{"DATACENTER": "fra1"},
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no template",
+			text: `ALERTS{alertname="RebootRequired"}`,
+			want: `ALERTS{alertname="RebootRequired"}`,
+		},
+		{
+			name: "hostname",
+			text: `ALERTS{alertname="RebootRequired", instance="{{ .Hostname }}"}`,
+			want: `ALERTS{alertname="RebootRequired", instance="node-1"}`,
+		},
+		{
+			name: "env",
+			text: `up{dc="{{ .Env.DATACENTER }}", instance=~"{{ .Hostname }}:.*"}`,
+			want: `up{dc="fra1", instance=~"node-1:.*"}`,
+		},
+		{
+			name:    "undefined env",
+			text:    `up{dc="{{ .Env.REGION }}"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid template",
+			text:    `up{instance="{{ .Hostname }"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderTemplate(tt.text, data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("renderTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("renderTemplate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_renderHostTemplate(t *testing.T) {
+	t.Setenv("CONDITIONAL_REBOOT_TEST", "value")
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("could not determine hostname: %v", err)
+	}
+
+	got, err := renderHostTemplate(`{{ .Hostname }}/{{ .Env.CONDITIONAL_REBOOT_TEST }}`)
+	if err != nil {
+		t.Fatalf("renderHostTemplate() error = %v", err)
+	}
+	if want := hostname + "/value"; got != want {
+		t.Errorf("renderHostTemplate() got = %v, want %v", got, want)
+	}
+}
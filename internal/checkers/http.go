@@ -0,0 +1,200 @@
+package checkers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	HttpCheckerName    = "http"
+	httpDefaultTimeout = 10 * time.Second
+	httpMaxBodySize    = 1024 * 1024
+)
+
+// HttpChecker performs a HTTP request and checks whether the response matches the expectations, i.e. the status code
+// and optionally the body.
+type HttpChecker struct {
+	url     string
+	method  string
+	headers map[string]string
+	timeout time.Duration
+
+	expectedStatusCodes []int
+	bodyRegex           *regexp.Regexp
+	jsonPath            string
+	jsonValue           *string
+
+	caFile   string
+	certFile string
+	keyFile  string
+
+	binding NetworkBinding
+	client  *http.Client
+}
+
+type HttpOpts func(checker *HttpChecker) error
+
+func NewHttpChecker(url string, opts ...HttpOpts) (*HttpChecker, error) {
+	if len(url) == 0 {
+		return nil, errors.New("empty 'url' provided")
+	}
+
+	checker := &HttpChecker{
+		url:     url,
+		method:  http.MethodGet,
+		timeout: httpDefaultTimeout,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	tlsConfig, err := buildTlsConfig(checker.caFile, checker.certFile, checker.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not build tls config: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if !checker.binding.IsZero() {
+		transport.DialContext = checker.binding.DialContext
+	}
+	checker.client = &http.Client{Transport: transport}
+
+	return checker, nil
+}
+
+func (c *HttpChecker) Name() string {
+	name := fmt.Sprintf("%s - %s %s", HttpCheckerName, c.method, c.url)
+	if !c.binding.IsZero() {
+		name += fmt.Sprintf(" (%s)", c.binding)
+	}
+	return name
+}
+
+func (c *HttpChecker) IsHealthy(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not build request: %w", err)
+	}
+
+	for key, val := range c.headers {
+		if strings.EqualFold(key, "host") {
+			req.Host = val
+		} else {
+			req.Header.Set(key, val)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		log.Error().Str("checker", "http").Err(err).Msgf("Request for checker '%s' failed", c.Name())
+		return false, nil
+	}
+	defer resp.Body.Close()
+
+	if !c.isExpectedStatusCode(resp.StatusCode) {
+		log.Warn().Str("checker", "http").Int("status", resp.StatusCode).Msgf("Unexpected status code for checker '%s'", c.Name())
+		return false, nil
+	}
+
+	if c.bodyRegex == nil && len(c.jsonPath) == 0 {
+		return true, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, httpMaxBodySize))
+	if err != nil {
+		log.Error().Str("checker", "http").Err(err).Msgf("Could not read body for checker '%s'", c.Name())
+		return false, nil
+	}
+
+	return c.evaluateBody(body), nil
+}
+
+func (c *HttpChecker) isExpectedStatusCode(statusCode int) bool {
+	if len(c.expectedStatusCodes) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+
+	for _, expected := range c.expectedStatusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *HttpChecker) evaluateBody(body []byte) bool {
+	if c.bodyRegex != nil && !c.bodyRegex.Match(body) {
+		log.Warn().Str("checker", "http").Msgf("Body does not match regex for checker '%s'", c.Name())
+		return false
+	}
+
+	if len(c.jsonPath) == 0 {
+		return true
+	}
+
+	// keep numbers as sent, as float64 they would be formatted in exponent notation
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		log.Warn().Str("checker", "http").Err(err).Msgf("Body is not valid json for checker '%s'", c.Name())
+		return false
+	}
+
+	val, err := lookupJsonPath(doc, c.jsonPath)
+	if err != nil || val == nil {
+		log.Warn().Str("checker", "http").Err(err).Msgf("Json path '%s' not found for checker '%s'", c.jsonPath, c.Name())
+		return false
+	}
+
+	if c.jsonValue != nil && !jsonValueEquals(val, *c.jsonValue) {
+		log.Warn().Str("checker", "http").Msgf("Json path '%s' has value '%v', expected '%s' for checker '%s'", c.jsonPath, val, *c.jsonValue, c.Name())
+		return false
+	}
+
+	return true
+}
+
+// jsonValueEquals compares a value of a json document with the expected value. Numbers are compared numerically, so
+// '1000000' matches 1e6 and 1000000.0.
+func jsonValueEquals(val any, expected string) bool {
+	num, ok := val.(json.Number)
+	if !ok {
+		return fmt.Sprintf("%v", val) == expected
+	}
+
+	if num.String() == expected {
+		return true
+	}
+
+	got, err := num.Float64()
+	if err != nil {
+		return false
+	}
+	want, err := strconv.ParseFloat(expected, 64)
+	return err == nil && got == want
+}
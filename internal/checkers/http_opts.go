@@ -0,0 +1,187 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func HttpMethod(method string) HttpOpts {
+	return func(checker *HttpChecker) error {
+		if len(method) == 0 {
+			return errors.New("empty method provided")
+		}
+
+		checker.method = strings.ToUpper(method)
+		return nil
+	}
+}
+
+func HttpHeaders(headers map[string]string) HttpOpts {
+	return func(checker *HttpChecker) error {
+		checker.headers = headers
+		return nil
+	}
+}
+
+func HttpTimeout(timeout time.Duration) HttpOpts {
+	return func(checker *HttpChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be > 0")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+func HttpExpectedStatusCodes(statusCodes []int) HttpOpts {
+	return func(checker *HttpChecker) error {
+		for _, statusCode := range statusCodes {
+			if statusCode < 100 || statusCode > 599 {
+				return fmt.Errorf("invalid status code %d", statusCode)
+			}
+		}
+
+		checker.expectedStatusCodes = statusCodes
+		return nil
+	}
+}
+
+func HttpBodyRegex(expr string) HttpOpts {
+	return func(checker *HttpChecker) error {
+		regex, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("could not compile body regex: %w", err)
+		}
+
+		checker.bodyRegex = regex
+		return nil
+	}
+}
+
+// HttpJsonPath expects the response body to be a json document that contains the given path. If value is not nil,
+// the value found at the path must equal the given value.
+func HttpJsonPath(path string, value *string) HttpOpts {
+	return func(checker *HttpChecker) error {
+		if _, err := parseJsonPath(path); err != nil {
+			return err
+		}
+
+		checker.jsonPath = path
+		checker.jsonValue = value
+		return nil
+	}
+}
+
+func HttpClientCerts(certFile, keyFile string) HttpOpts {
+	return func(checker *HttpChecker) error {
+		checker.certFile = certFile
+		checker.keyFile = keyFile
+		return nil
+	}
+}
+
+func HttpCaFile(caFile string) HttpOpts {
+	return func(checker *HttpChecker) error {
+		checker.caFile = caFile
+		return nil
+	}
+}
+
+// HttpBinding sends requests from a source address, an interface or a network namespace.
+func HttpBinding(binding NetworkBinding) HttpOpts {
+	return func(checker *HttpChecker) error {
+		if err := binding.validate(); err != nil {
+			return err
+		}
+
+		checker.binding = binding
+		return nil
+	}
+}
+
+//nolint:cyclop
+func HttpCheckerFromMap(args map[string]any) (*HttpChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build http checker, empty args supplied")
+	}
+
+	url, ok := args["url"].(string)
+	if !ok {
+		return nil, errors.New("could not build http checker, no 'url' supplied")
+	}
+
+	var opts []HttpOpts
+	if method, ok := args["method"].(string); ok {
+		opts = append(opts, HttpMethod(method))
+	}
+
+	headers, ok, err := stringMapFromArgs(args, "headers")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, HttpHeaders(headers))
+	}
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, HttpTimeout(timeout))
+	}
+
+	statusCodesRaw, ok, err := stringSliceFromArgs(args, "expected_status")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		var statusCodes []int
+		for _, raw := range statusCodesRaw {
+			statusCode, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid status code '%s'", raw)
+			}
+			statusCodes = append(statusCodes, statusCode)
+		}
+		opts = append(opts, HttpExpectedStatusCodes(statusCodes))
+	}
+
+	if bodyRegex, ok := args["body_regex"].(string); ok {
+		opts = append(opts, HttpBodyRegex(bodyRegex))
+	}
+
+	if jsonPath, ok := args["json_path"].(string); ok {
+		var jsonValue *string
+		if val, ok := args["json_value"]; ok {
+			formatted := fmt.Sprintf("%v", val)
+			if num, ok := val.(float64); ok {
+				// avoid exponent notation of large numbers
+				formatted = strconv.FormatFloat(num, 'f', -1, 64)
+			}
+			jsonValue = &formatted
+		}
+		opts = append(opts, HttpJsonPath(jsonPath, jsonValue))
+	}
+
+	clientCert, okCert := args["tls_client_cert"].(string)
+	clientKey, okKey := args["tls_client_key"].(string)
+	if okCert || okKey {
+		opts = append(opts, HttpClientCerts(clientCert, clientKey))
+	}
+
+	if caFile, ok := args["tls_ca"].(string); ok {
+		opts = append(opts, HttpCaFile(caFile))
+	}
+
+	if binding, ok := networkBindingFromArgs(args); ok {
+		opts = append(opts, HttpBinding(binding))
+	}
+
+	return NewHttpChecker(url, opts...)
+}
@@ -0,0 +1,354 @@
+package checkers
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHttpChecker_IsHealthy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthy":
+			_, _ = w.Write([]byte(`{"status": "ok", "checks": [{"name": "db", "healthy": true}]}`))
+		case "/degraded":
+			_, _ = w.Write([]byte(`{"status": "degraded", "checks": [{"name": "db", "healthy": false}]}`))
+		case "/stats":
+			_, _ = w.Write([]byte(`{"connections": 1000000, "ratio": 0.5, "id": 9007199254740993}`))
+		case "/header":
+			if r.Header.Get("X-Token") != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	ok := "ok"
+	boolTrue := "true"
+	million := "1000000"
+	millionExponent := "1e6"
+	millionAndOne := "1000001"
+	half := "0.5"
+	largeId := "9007199254740993"
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    []HttpOpts
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "status ok",
+			path: "/healthy",
+			want: true,
+		},
+		{
+			name: "unexpected status code",
+			path: "/unavailable",
+			want: false,
+		},
+		{
+			name: "expected status code",
+			path: "/unavailable",
+			opts: []HttpOpts{HttpExpectedStatusCodes([]int{503})},
+			want: true,
+		},
+		{
+			name: "body matches regex",
+			path: "/healthy",
+			opts: []HttpOpts{HttpBodyRegex(`"status":\s*"ok"`)},
+			want: true,
+		},
+		{
+			name: "body doesn't match regex",
+			path: "/degraded",
+			opts: []HttpOpts{HttpBodyRegex(`"status":\s*"ok"`)},
+			want: false,
+		},
+		{
+			name: "json path matches",
+			path: "/healthy",
+			opts: []HttpOpts{HttpJsonPath("$.status", &ok)},
+			want: true,
+		},
+		{
+			name: "nested json path matches",
+			path: "/healthy",
+			opts: []HttpOpts{HttpJsonPath("$.checks[0].healthy", &boolTrue)},
+			want: true,
+		},
+		{
+			name: "json path doesn't match",
+			path: "/degraded",
+			opts: []HttpOpts{HttpJsonPath("$.checks[0].healthy", &boolTrue)},
+			want: false,
+		},
+		{
+			name: "json path matches large integer",
+			path: "/stats",
+			opts: []HttpOpts{HttpJsonPath("$.connections", &million)},
+			want: true,
+		},
+		{
+			name: "json path matches number in exponent notation",
+			path: "/stats",
+			opts: []HttpOpts{HttpJsonPath("$.connections", &millionExponent)},
+			want: true,
+		},
+		{
+			name: "json path doesn't match large integer",
+			path: "/stats",
+			opts: []HttpOpts{HttpJsonPath("$.connections", &millionAndOne)},
+			want: false,
+		},
+		{
+			name: "json path matches float",
+			path: "/stats",
+			opts: []HttpOpts{HttpJsonPath("$.ratio", &half)},
+			want: true,
+		},
+		{
+			name: "json path matches integer beyond float precision",
+			path: "/stats",
+			opts: []HttpOpts{HttpJsonPath("$.id", &largeId)},
+			want: true,
+		},
+		{
+			name: "json path missing",
+			path: "/healthy",
+			opts: []HttpOpts{HttpJsonPath("$.missing", nil)},
+			want: false,
+		},
+		{
+			name: "headers sent",
+			path: "/header",
+			opts: []HttpOpts{HttpHeaders(map[string]string{"X-Token": "secret"})},
+			want: true,
+		},
+		{
+			name: "headers missing",
+			path: "/header",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewHttpChecker(server.URL+tt.path, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewHttpChecker() error = %v", err)
+			}
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHttpChecker_IsHealthy_CustomCa(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPem, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opts []HttpOpts
+		want bool
+	}{
+		{
+			name: "unknown ca",
+			want: false,
+		},
+		{
+			name: "custom ca",
+			opts: []HttpOpts{HttpCaFile(caFile)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewHttpChecker(server.URL, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewHttpChecker() error = %v", err)
+			}
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHttpCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    map[string]any{},
+			wantErr: true,
+		},
+		{
+			name: "minimal",
+			args: map[string]any{
+				"url": "https://example.com/health",
+			},
+		},
+		{
+			name: "full",
+			args: map[string]any{
+				"url":             "https://example.com/health",
+				"method":          "head",
+				"headers":         map[string]any{"Authorization": "Bearer x"},
+				"expected_status": []any{200, 204},
+				"body_regex":      "ok",
+				"json_path":       "$.status",
+				"json_value":      "ok",
+				"timeout":         "5s",
+			},
+		},
+		{
+			name: "invalid regex",
+			args: map[string]any{
+				"url":        "https://example.com/health",
+				"body_regex": "(",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid status",
+			args: map[string]any{
+				"url":             "https://example.com/health",
+				"expected_status": []any{"ok"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "only client cert",
+			args: map[string]any{
+				"url":             "https://example.com/health",
+				"tls_client_cert": "/etc/ssl/client.crt",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := HttpCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HttpCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHttpCheckerFromMap_JsonValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"connections": 1000000}`))
+	}))
+	defer server.Close()
+
+	// numbers as decoded from the yaml config
+	for _, value := range []any{1000000, 1e6} {
+		c, err := HttpCheckerFromMap(map[string]any{
+			"url":        server.URL,
+			"json_path":  "$.connections",
+			"json_value": value,
+		})
+		if err != nil {
+			t.Fatalf("HttpCheckerFromMap() error = %v", err)
+		}
+
+		got, err := c.IsHealthy(context.Background())
+		if err != nil || !got {
+			t.Errorf("IsHealthy() json_value %v got = %v, err = %v, want true", value, got, err)
+		}
+	}
+}
+
+func TestLookupJsonPath(t *testing.T) {
+	doc := map[string]any{
+		"status": "ok",
+		"checks": []any{
+			map[string]any{"name": "db", "healthy": true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		want    any
+		wantErr bool
+	}{
+		{
+			name: "root",
+			path: "$",
+			want: doc,
+		},
+		{
+			name: "child",
+			path: "$.status",
+			want: "ok",
+		},
+		{
+			name: "bracket notation",
+			path: "$['checks'][0]['healthy']",
+			want: true,
+		},
+		{
+			name: "mixed notation",
+			path: "$.checks[0].name",
+			want: "db",
+		},
+		{
+			name:    "index out of bounds",
+			path:    "$.checks[1].name",
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			path:    "$.missing",
+			wantErr: true,
+		},
+		{
+			name:    "invalid path",
+			path:    "status",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lookupJsonPath(doc, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("lookupJsonPath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookupJsonPath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// httpClientSettings holds everything that influences how requests to an API are authenticated and transported.
+// Clients are only shared between checkers with identical settings.
+type httpClientSettings struct {
+	caFile          string
+	clientCertFile  string
+	clientKeyFile   string
+	bearerTokenFile string
+	username        string
+	password        string
+	passwordFile    string
+	proxy           string
+	binding         NetworkBinding
+}
+
+func (s httpClientSettings) key() string {
+	return strings.Join([]string{
+		s.caFile,
+		s.clientCertFile,
+		s.clientKeyFile,
+		s.bearerTokenFile,
+		s.username,
+		s.password,
+		s.passwordFile,
+		s.proxy,
+		s.binding.String(),
+	}, "\x00")
+}
+
+func (s httpClientSettings) validate() error {
+	if len(s.bearerTokenFile) > 0 && len(s.username) > 0 {
+		return errors.New("bearer token and basic auth are mutually exclusive")
+	}
+
+	if len(s.password) > 0 && len(s.passwordFile) > 0 {
+		return errors.New("password and password file are mutually exclusive")
+	}
+
+	if (len(s.password) > 0 || len(s.passwordFile) > 0) && len(s.username) == 0 {
+		return errors.New("password supplied without username")
+	}
+
+	return nil
+}
+
+// httpClientSettingsFromArgs parses the TLS, authentication, proxy and network binding settings that are shared by
+// all checkers talking to an HTTP API.
+func httpClientSettingsFromArgs(args map[string]any) (httpClientSettings, error) {
+	var settings httpClientSettings
+
+	var okCert, okKey bool
+	settings.clientCertFile, okCert = args["tls_client_cert"].(string)
+	settings.clientKeyFile, okKey = args["tls_client_key"].(string)
+	if (okCert || okKey) && (len(settings.clientCertFile) == 0 || len(settings.clientKeyFile) == 0) {
+		return settings, errors.New("both 'tls_client_cert' and 'tls_client_key' need to be supplied")
+	}
+
+	settings.caFile, _ = args["tls_ca"].(string)
+	settings.bearerTokenFile, _ = args["bearer_token_file"].(string)
+	settings.username, _ = args["username"].(string)
+	settings.password, _ = args["password"].(string)
+	settings.passwordFile, _ = args["password_file"].(string)
+	settings.proxy, _ = args["proxy"].(string)
+
+	if binding, ok := networkBindingFromArgs(args); ok {
+		if err := binding.validate(); err != nil {
+			return settings, err
+		}
+		settings.binding = binding
+	}
+
+	if err := settings.validate(); err != nil {
+		return settings, err
+	}
+
+	return settings, nil
+}
+
+// buildRetryableHttpClient returns a client that retries failed requests and authenticates each request according to
+// the settings. Credentials stored in files are read on each request, so rotated secrets are picked up.
+func buildRetryableHttpClient(settings httpClientSettings) (*http.Client, error) {
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := buildTlsConfig(settings.caFile, settings.clientCertFile, settings.clientKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if len(settings.proxy) > 0 {
+		proxyUrl, err := url.Parse(settings.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	if !settings.binding.IsZero() {
+		transport.DialContext = settings.binding.DialContext
+	}
+
+	cl := retryablehttp.NewClient()
+	cl.Logger = &ZerologAdapter{}
+	cl.RetryMax = 3
+	cl.HTTPClient.Transport = &authRoundTripper{
+		settings: settings,
+		next:     transport,
+	}
+
+	return cl.StandardClient(), nil
+}
+
+type authRoundTripper struct {
+	settings httpClientSettings
+	next     http.RoundTripper
+}
+
+func (a *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(a.settings.bearerTokenFile) == 0 && len(a.settings.username) == 0 {
+		return a.next.RoundTrip(req)
+	}
+
+	// a RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+
+	if len(a.settings.bearerTokenFile) > 0 {
+		token, err := readSecretFile(a.settings.bearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if len(a.settings.username) > 0 {
+		password := a.settings.password
+		if len(a.settings.passwordFile) > 0 {
+			var err error
+			password, err = readSecretFile(a.settings.passwordFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read password: %w", err)
+			}
+		}
+		req.SetBasicAuth(a.settings.username, password)
+	}
+
+	return a.next.RoundTrip(req)
+}
+
+func readSecretFile(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
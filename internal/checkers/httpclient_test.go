@@ -0,0 +1,73 @@
+package checkers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_httpClientSettingsFromArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    httpClientSettings
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: map[string]any{},
+		},
+		{
+			name: "all settings",
+			args: map[string]any{
+				"tls_client_cert": "/etc/ssl/client.crt",
+				"tls_client_key":  "/etc/ssl/client.key",
+				"tls_ca":          "/etc/ssl/ca.crt",
+				"username":        "u",
+				"password_file":   "/etc/secret",
+				"proxy":           "http://proxy:3128",
+				"source_ip":       "192.0.2.1",
+			},
+			want: httpClientSettings{
+				caFile:         "/etc/ssl/ca.crt",
+				clientCertFile: "/etc/ssl/client.crt",
+				clientKeyFile:  "/etc/ssl/client.key",
+				username:       "u",
+				passwordFile:   "/etc/secret",
+				proxy:          "http://proxy:3128",
+				binding:        NetworkBinding{SourceIp: "192.0.2.1"},
+			},
+		},
+		{
+			name:    "client cert without key",
+			args:    map[string]any{"tls_client_cert": "/etc/ssl/client.crt"},
+			wantErr: true,
+		},
+		{
+			name:    "password without username",
+			args:    map[string]any{"password": "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "bearer token and basic auth",
+			args:    map[string]any{"bearer_token_file": "/etc/token", "username": "u"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid source ip",
+			args:    map[string]any{"source_ip": "wan0"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := httpClientSettingsFromArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("httpClientSettingsFromArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("httpClientSettingsFromArgs() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,32 +4,144 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	probing "github.com/prometheus-community/pro-bing"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+
+	probing "github.com/prometheus-community/pro-bing"
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
 )
 
 const (
 	IcmpCheckerName    = "icmp"
 	icmpDefaultTimeout = 3 * time.Second
+	icmpDefaultCount   = 1
+	// pro-bing's default interval
+	icmpDefaultInterval = time.Second
 )
 
+// PingSettings configures how a host is pinged.
+type PingSettings struct {
+	Count      int
+	Interval   time.Duration
+	Timeout    time.Duration
+	Size       int
+	TTL        int
+	Network    string
+	Privileged bool
+	Source     string
+	Interface  string
+}
+
+// PingResult holds the statistics of a ping run.
+type PingResult struct {
+	Sent     int
+	Received int
+	Rtts     []time.Duration
+}
+
+// Pinger sends ICMP echo requests to a host.
+type Pinger interface {
+	Ping(ctx context.Context, host string, settings PingSettings) (*PingResult, error)
+}
+
+type proBingPinger struct{}
+
+func (p *proBingPinger) Ping(ctx context.Context, host string, settings PingSettings) (*PingResult, error) {
+	if len(settings.Interface) > 0 {
+		return pingBoundSocket(ctx, host, settings)
+	}
+
+	pinger := probing.New(host)
+	pinger.SetNetwork(settings.Network)
+	if err := pinger.Resolve(); err != nil {
+		return nil, fmt.Errorf("could not resolve %s: %w", host, err)
+	}
+
+	pinger.Source = settings.Source
+
+	pinger.Count = settings.Count
+	pinger.Interval = settings.Interval
+	pinger.Timeout = settings.Timeout
+	if settings.Size > 0 {
+		pinger.Size = settings.Size
+	}
+	if settings.TTL > 0 {
+		pinger.TTL = settings.TTL
+	}
+	pinger.SetPrivileged(settings.Privileged)
+
+	if err := pinger.RunWithContext(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := pinger.Statistics()
+	return &PingResult{
+		Sent:     stats.PacketsSent,
+		Received: stats.PacketsRecv,
+		Rtts:     stats.Rtts,
+	}, nil
+}
+
+// IcmpChecker pings a host and reports an unhealthy state if the packet loss or the round-trip times exceed the
+// configured thresholds. By default, a single echo request needs to be answered.
 type IcmpChecker struct {
 	host       string
 	timeout    time.Duration
+	timeoutSet bool
 	privileged bool
+	count      int
+	interval   time.Duration
+	size       int
+	ttl        int
+	network    string
+	maxLoss    float64
+	maxAvgRtt  time.Duration
+	maxP95Rtt  time.Duration
+	binding    NetworkBinding
+	pinger     Pinger
+
+	mutex  sync.Mutex
+	reason string
 }
 
-func NewIcmpChecker(host string) (*IcmpChecker, error) {
+type IcmpOpts func(checker *IcmpChecker) error
+
+func NewIcmpChecker(host string, opts ...IcmpOpts) (*IcmpChecker, error) {
 	if len(host) == 0 {
 		return nil, errors.New("empty host provided")
 	}
 
-	return &IcmpChecker{
+	checker := &IcmpChecker{
 		host:       host,
 		timeout:    icmpDefaultTimeout,
 		privileged: getPrivilegedDefaultForPlatform(),
-	}, nil
+		count:      icmpDefaultCount,
+		interval:   icmpDefaultInterval,
+		network:    "ip",
+		pinger:     &proBingPinger{},
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	// leave enough time to send all packets if no timeout has been configured explicitly
+	if !checker.timeoutSet {
+		checker.timeout = time.Duration(checker.count-1)*checker.interval + icmpDefaultTimeout
+	}
+
+	return checker, nil
 }
 
 func getPrivilegedDefaultForPlatform() bool {
@@ -43,61 +155,110 @@ func getPrivilegedDefaultForPlatform() bool {
 	return false
 }
 
-func IcmpCheckerFromMap(args map[string]any) (*IcmpChecker, error) {
-	if args == nil {
-		return nil, errors.New("empty args supplied")
+func (c *IcmpChecker) Name() string {
+	isPrivileged := "privileged"
+	if !c.privileged {
+		isPrivileged = "un" + isPrivileged
 	}
 
-	host, ok := args["host"]
-	if !ok {
-		return nil, errors.New("no 'host' supplied")
+	if !c.binding.IsZero() {
+		isPrivileged += ", " + c.binding.String()
 	}
 
-	checker, err := NewIcmpChecker(fmt.Sprintf("%s", host))
-	if err != nil {
-		return nil, err
+	return fmt.Sprintf("%s://%s (%s)", IcmpCheckerName, c.host, isPrivileged)
+}
+
+func (c *IcmpChecker) IsHealthy(ctx context.Context) (bool, error) {
+	settings := PingSettings{
+		Count:      c.count,
+		Interval:   c.interval,
+		Timeout:    c.timeout,
+		Size:       c.size,
+		TTL:        c.ttl,
+		Network:    c.network,
+		Privileged: c.privileged,
+		Source:     c.binding.SourceIp,
+		Interface:  c.binding.Interface,
 	}
 
-	timeoutHuman, ok := args["timeout"].(string)
-	if ok {
-		timeout, err := time.ParseDuration(timeoutHuman)
-		if err != nil {
-			return nil, fmt.Errorf("timeout duration could not be parsed: %w", err)
-		}
-		checker.timeout = timeout
+	var result *PingResult
+	err := c.binding.run(func() error {
+		var err error
+		result, err = c.pinger.Ping(ctx, c.host, settings)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("ping unsuccessful: %w", err)
 	}
 
-	privileged, ok := args["privileged"].(bool)
-	if ok {
-		checker.privileged = privileged
+	problems := c.evaluate(result)
+
+	c.mutex.Lock()
+	c.reason = strings.Join(problems, ", ")
+	c.mutex.Unlock()
+
+	if len(problems) > 0 {
+		log.Warn().Str("checker", "icmp").Strs("problems", problems).Msgf("Checker '%s' failed", c.Name())
+		return false, nil
 	}
 
-	return checker, nil
+	return true, nil
 }
 
-func (c *IcmpChecker) Name() string {
-	isPrivileged := "privileged"
-	if !c.privileged {
-		isPrivileged = "un" + isPrivileged
+func (c *IcmpChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
+
+func (c *IcmpChecker) evaluate(result *PingResult) []string {
+	// packets that were not sent due to the timeout count as lost
+	sent := c.count
+	if result.Sent > sent {
+		sent = result.Sent
 	}
 
-	return fmt.Sprintf("%s://%s (%s)", IcmpCheckerName, c.host, isPrivileged)
-}
+	if result.Received == 0 {
+		return []string{fmt.Sprintf("no reply to %d packets", sent)}
+	}
 
-func (c *IcmpChecker) IsHealthy(ctx context.Context) (bool, error) {
-	pinger, err := probing.NewPinger(c.host)
-	if err != nil {
-		return false, fmt.Errorf("could not create pinger: %w", err)
+	var problems []string
+	loss := float64(sent-result.Received) / float64(sent) * 100
+	if loss > c.maxLoss {
+		problems = append(problems, fmt.Sprintf("packet loss %.1f%% exceeds %.1f%%", loss, c.maxLoss))
 	}
 
-	count := 1
-	pinger.Timeout = 3 * time.Second
-	pinger.Count = count
-	pinger.SetPrivileged(c.privileged)
-	if err := pinger.RunWithContext(ctx); err != nil {
-		return false, fmt.Errorf("ping unsuccessful: %w", err)
+	if c.maxAvgRtt > 0 && len(result.Rtts) > 0 {
+		var total time.Duration
+		for _, rtt := range result.Rtts {
+			total += rtt
+		}
+		avg := total / time.Duration(len(result.Rtts))
+		if avg > c.maxAvgRtt {
+			problems = append(problems, fmt.Sprintf("avg rtt %s exceeds %s", avg, c.maxAvgRtt))
+		}
 	}
 
-	stats := pinger.Statistics()
-	return stats.PacketsRecv == count, nil
+	if c.maxP95Rtt > 0 && len(result.Rtts) > 0 {
+		p95 := percentileDuration(result.Rtts, 95)
+		if p95 > c.maxP95Rtt {
+			problems = append(problems, fmt.Sprintf("p95 rtt %s exceeds %s", p95, c.maxP95Rtt))
+		}
+	}
+
+	return problems
+}
+
+// percentileDuration returns the percentile using the nearest-rank method.
+func percentileDuration(durations []time.Duration, percentile int) time.Duration {
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
 }
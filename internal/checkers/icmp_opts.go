@@ -0,0 +1,194 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+func IcmpTimeout(timeout time.Duration) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		checker.timeout = timeout
+		checker.timeoutSet = true
+		return nil
+	}
+}
+
+func IcmpPrivileged(privileged bool) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		checker.privileged = privileged
+		return nil
+	}
+}
+
+func IcmpCount(count int) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if count < 1 {
+			return fmt.Errorf("count must be at least 1, got %d", count)
+		}
+
+		checker.count = count
+		return nil
+	}
+}
+
+func IcmpInterval(interval time.Duration) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if interval <= 0 {
+			return errors.New("interval must be positive")
+		}
+
+		checker.interval = interval
+		return nil
+	}
+}
+
+// IcmpSize sets the size of the payload in bytes.
+func IcmpSize(size int) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if size < 0 || size > 65507 {
+			return fmt.Errorf("invalid packet size %d", size)
+		}
+
+		checker.size = size
+		return nil
+	}
+}
+
+func IcmpTTL(ttl int) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if ttl < 1 || ttl > 255 {
+			return fmt.Errorf("invalid ttl %d", ttl)
+		}
+
+		checker.ttl = ttl
+		return nil
+	}
+}
+
+// IcmpNetwork selects the address family the host is resolved to: 'ip' for either, 'ip4' or 'ip6'.
+func IcmpNetwork(network string) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		switch network {
+		case "ip", "ip4", "ip6":
+			checker.network = network
+			return nil
+		}
+
+		return fmt.Errorf("unsupported network %q", network)
+	}
+}
+
+// IcmpMaxLoss sets the acceptable packet loss in percent.
+func IcmpMaxLoss(maxLoss float64) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if maxLoss < 0 || maxLoss >= 100 {
+			return fmt.Errorf("max loss must be within [0, 100), got %v", maxLoss)
+		}
+
+		checker.maxLoss = maxLoss
+		return nil
+	}
+}
+
+func IcmpMaxAvgRtt(maxAvgRtt time.Duration) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if maxAvgRtt <= 0 {
+			return errors.New("max avg rtt must be positive")
+		}
+
+		checker.maxAvgRtt = maxAvgRtt
+		return nil
+	}
+}
+
+func IcmpMaxP95Rtt(maxP95Rtt time.Duration) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if maxP95Rtt <= 0 {
+			return errors.New("max p95 rtt must be positive")
+		}
+
+		checker.maxP95Rtt = maxP95Rtt
+		return nil
+	}
+}
+
+// IcmpBinding sends the echo requests from a source address, an interface or a network namespace.
+func IcmpBinding(binding NetworkBinding) IcmpOpts {
+	return func(checker *IcmpChecker) error {
+		if err := binding.validate(); err != nil {
+			return err
+		}
+
+		checker.binding = binding
+		return nil
+	}
+}
+
+//nolint:cyclop
+func IcmpCheckerFromMap(args map[string]any) (*IcmpChecker, error) {
+	if args == nil {
+		return nil, errors.New("empty args supplied")
+	}
+
+	host, ok := args["host"]
+	if !ok {
+		return nil, errors.New("no 'host' supplied")
+	}
+
+	var opts []IcmpOpts
+	for key, opt := range map[string]func(time.Duration) IcmpOpts{
+		"timeout":     IcmpTimeout,
+		"interval":    IcmpInterval,
+		"max_avg_rtt": IcmpMaxAvgRtt,
+		"max_p95_rtt": IcmpMaxP95Rtt,
+	} {
+		duration, ok, err := durationFromArgs(args, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			opts = append(opts, opt(duration))
+		}
+	}
+
+	for key, opt := range map[string]func(int) IcmpOpts{
+		"count": IcmpCount,
+		"size":  IcmpSize,
+		"ttl":   IcmpTTL,
+	} {
+		num, ok, err := intFromArgs(args, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			opts = append(opts, opt(num))
+		}
+	}
+
+	maxLoss, ok, err := floatFromArgs(args, "max_loss")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, IcmpMaxLoss(maxLoss))
+	}
+
+	if network, ok := args["network"].(string); ok {
+		opts = append(opts, IcmpNetwork(network))
+	}
+
+	if privileged, ok := args["privileged"].(bool); ok {
+		opts = append(opts, IcmpPrivileged(privileged))
+	}
+
+	if binding, ok := networkBindingFromArgs(args); ok {
+		opts = append(opts, IcmpBinding(binding))
+	}
+
+	return NewIcmpChecker(fmt.Sprintf("%s", host), opts...)
+}
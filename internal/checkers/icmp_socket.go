@@ -0,0 +1,168 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	icmpProtocolIpv4 = 1
+	icmpProtocolIpv6 = 58
+	// pro-bing's default payload size
+	icmpDefaultSize = 24
+)
+
+// pingBoundSocket pings the host using an ICMP socket that is bound to an interface. pro-bing does not allow setting
+// SO_BINDTODEVICE on its socket, so the echo requests are sent and received here instead.
+//
+//nolint:cyclop
+func pingBoundSocket(ctx context.Context, host string, settings PingSettings) (*PingResult, error) {
+	dst, err := net.ResolveIPAddr(settings.Network, host)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve %s: %w", host, err)
+	}
+
+	isIpv4 := dst.IP.To4() != nil
+	conn, err := listenIcmp(isIpv4, settings.Privileged, settings.Source, settings.Interface)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var (
+		proto               = icmpProtocolIpv6
+		echoType  icmp.Type = ipv6.ICMPTypeEchoRequest
+		replyType icmp.Type = ipv6.ICMPTypeEchoReply
+	)
+	if isIpv4 {
+		proto, echoType, replyType = icmpProtocolIpv4, ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	}
+
+	if settings.TTL > 0 {
+		if isIpv4 {
+			err = ipv4.NewPacketConn(conn).SetTTL(settings.TTL)
+		} else {
+			err = ipv6.NewPacketConn(conn).SetHopLimit(settings.TTL)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not set ttl: %w", err)
+		}
+	}
+
+	// datagram oriented ping sockets are addressed like udp sockets
+	var addr net.Addr = dst
+	if !settings.Privileged {
+		addr = &net.UDPAddr{IP: dst.IP, Zone: dst.Zone}
+	}
+
+	size := settings.Size
+	if size <= 0 {
+		size = icmpDefaultSize
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, settings.Timeout)
+	defer cancel()
+
+	// #nosec G404
+	id := rand.Intn(0xffff)
+	var (
+		mutex   sync.Mutex
+		sentAt  = map[int]time.Time{}
+		sendErr error
+		wg      sync.WaitGroup
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for seq := 0; seq < settings.Count; seq++ {
+			msg := icmp.Message{Type: echoType, Body: &icmp.Echo{ID: id, Seq: seq, Data: make([]byte, size)}}
+			data, err := msg.Marshal(nil)
+			if err == nil {
+				mutex.Lock()
+				sentAt[seq] = time.Now()
+				mutex.Unlock()
+				_, err = conn.WriteTo(data, addr)
+			}
+			if err != nil {
+				mutex.Lock()
+				sendErr = fmt.Errorf("could not send echo request: %w", err)
+				mutex.Unlock()
+				cancel()
+				return
+			}
+
+			if seq < settings.Count-1 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(settings.Interval):
+				}
+			}
+		}
+	}()
+
+	go func() {
+		// unblock the pending read
+		<-ctx.Done()
+		_ = conn.SetReadDeadline(time.Now())
+	}()
+
+	result := &PingResult{}
+	received := map[int]bool{}
+	buf := make([]byte, size+128)
+	for len(received) < settings.Count {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				break
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			return nil, fmt.Errorf("could not receive echo reply: %w", err)
+		}
+		now := time.Now()
+
+		msg, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil || msg.Type != replyType {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		// the kernel rewrites the id of datagram oriented ping sockets and only passes on their own replies
+		if !ok || (settings.Privileged && echo.ID != id) || received[echo.Seq] {
+			continue
+		}
+
+		mutex.Lock()
+		sent, ok := sentAt[echo.Seq]
+		mutex.Unlock()
+		if !ok {
+			continue
+		}
+
+		received[echo.Seq] = true
+		result.Rtts = append(result.Rtts, now.Sub(sent))
+	}
+
+	cancel()
+	wg.Wait()
+
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	result.Sent = len(sentAt)
+	result.Received = len(received)
+	return result, nil
+}
@@ -0,0 +1,188 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type pingerDummy struct {
+	result   *PingResult
+	err      error
+	settings PingSettings
+}
+
+func (p *pingerDummy) Ping(_ context.Context, _ string, settings PingSettings) (*PingResult, error) {
+	p.settings = settings
+	return p.result, p.err
+}
+
+func rtts(millis ...int) []time.Duration {
+	ret := make([]time.Duration, 0, len(millis))
+	for _, m := range millis {
+		ret = append(ret, time.Duration(m)*time.Millisecond)
+	}
+	return ret
+}
+
+func TestIcmpChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []IcmpOpts
+		result     *PingResult
+		err        error
+		want       bool
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:   "single reply",
+			result: &PingResult{Sent: 1, Received: 1, Rtts: rtts(10)},
+			want:   true,
+		},
+		{
+			name:       "no reply",
+			result:     &PingResult{Sent: 1, Received: 0},
+			want:       false,
+			wantReason: "no reply to 1 packets",
+		},
+		{
+			name:   "acceptable loss",
+			opts:   []IcmpOpts{IcmpCount(10), IcmpMaxLoss(20)},
+			result: &PingResult{Sent: 10, Received: 8, Rtts: rtts(10, 10, 10, 10, 10, 10, 10, 10)},
+			want:   true,
+		},
+		{
+			name:       "loss exceeded",
+			opts:       []IcmpOpts{IcmpCount(10), IcmpMaxLoss(20)},
+			result:     &PingResult{Sent: 10, Received: 7, Rtts: rtts(10, 10, 10, 10, 10, 10, 10)},
+			want:       false,
+			wantReason: "packet loss 30.0% exceeds 20.0%",
+		},
+		{
+			name:       "packets not sent due to timeout count as lost",
+			opts:       []IcmpOpts{IcmpCount(4), IcmpMaxLoss(25)},
+			result:     &PingResult{Sent: 2, Received: 2, Rtts: rtts(10, 10)},
+			want:       false,
+			wantReason: "packet loss 50.0% exceeds 25.0%",
+		},
+		{
+			name:       "avg rtt exceeded",
+			opts:       []IcmpOpts{IcmpCount(4), IcmpMaxAvgRtt(50 * time.Millisecond)},
+			result:     &PingResult{Sent: 4, Received: 4, Rtts: rtts(40, 50, 60, 70)},
+			want:       false,
+			wantReason: "avg rtt 55ms exceeds 50ms",
+		},
+		{
+			name:   "p95 rtt within threshold",
+			opts:   []IcmpOpts{IcmpCount(20), IcmpMaxP95Rtt(100 * time.Millisecond)},
+			result: &PingResult{Sent: 20, Received: 20, Rtts: rtts(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 500)},
+			want:   true,
+		},
+		{
+			name:       "p95 rtt exceeded",
+			opts:       []IcmpOpts{IcmpCount(20), IcmpMaxP95Rtt(100 * time.Millisecond)},
+			result:     &PingResult{Sent: 20, Received: 20, Rtts: rtts(10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 200, 500)},
+			want:       false,
+			wantReason: "p95 rtt 200ms exceeds 100ms",
+		},
+		{
+			name:    "pinger error",
+			err:     errors.New("socket: permission denied"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewIcmpChecker("192.0.2.1", tt.opts...)
+			if err != nil {
+				t.Fatalf("NewIcmpChecker() error = %v", err)
+			}
+			c.pinger = &pingerDummy{result: tt.result, err: tt.err}
+
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestIcmpCheckerFromMap(t *testing.T) {
+	c, err := IcmpCheckerFromMap(map[string]any{
+		"host":       "192.0.2.1",
+		"count":      5,
+		"interval":   "200ms",
+		"timeout":    "2s",
+		"size":       56,
+		"ttl":        64,
+		"network":    "ip6",
+		"max_loss":   20,
+		"privileged": false,
+		"source_ip":  "192.0.2.10",
+	})
+	if err != nil {
+		t.Fatalf("IcmpCheckerFromMap() error = %v", err)
+	}
+
+	dummy := &pingerDummy{result: &PingResult{Sent: 5, Received: 5, Rtts: rtts(1, 1, 1, 1, 1)}}
+	c.pinger = dummy
+	if _, err := c.IsHealthy(context.Background()); err != nil {
+		t.Fatalf("IsHealthy() error = %v", err)
+	}
+
+	want := PingSettings{Count: 5, Interval: 200 * time.Millisecond, Timeout: 2 * time.Second, Size: 56, TTL: 64, Network: "ip6", Privileged: false, Source: "192.0.2.10"}
+	if dummy.settings != want {
+		t.Errorf("settings = %+v, want %+v", dummy.settings, want)
+	}
+
+	if _, err := IcmpCheckerFromMap(map[string]any{"host": "192.0.2.1", "network": "ipx"}); err == nil {
+		t.Errorf("IcmpCheckerFromMap() expected error for invalid network")
+	}
+
+}
+
+func TestNewIcmpChecker_DefaultTimeout(t *testing.T) {
+	c, err := NewIcmpChecker("192.0.2.1", IcmpCount(5), IcmpInterval(500*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewIcmpChecker() error = %v", err)
+	}
+
+	if want := 2*time.Second + icmpDefaultTimeout; c.timeout != want {
+		t.Errorf("timeout = %v, want %v", c.timeout, want)
+	}
+}
+
+func TestIcmpChecker_IsHealthy_Interface(t *testing.T) {
+	if !networkBindingSupported {
+		t.Skip("binding to an interface is not supported on this platform")
+	}
+
+	for _, privileged := range []bool{true, false} {
+		c, err := NewIcmpChecker("127.0.0.1", IcmpPrivileged(privileged), IcmpNetwork("ip4"), IcmpCount(2),
+			IcmpInterval(10*time.Millisecond), IcmpBinding(NetworkBinding{Interface: "lo"}))
+		if err != nil {
+			t.Fatalf("NewIcmpChecker() error = %v", err)
+		}
+
+		got, err := c.IsHealthy(context.Background())
+		if err != nil {
+			// raw sockets require CAP_NET_RAW, ping sockets net.ipv4.ping_group_range
+			t.Logf("IsHealthy() privileged=%v error = %v", privileged, err)
+			continue
+		}
+		if !got {
+			t.Errorf("IsHealthy() privileged=%v got = %v, want true, reason: %s", privileged, got, c.Reason())
+		}
+		return
+	}
+	t.Skip("not allowed to open icmp sockets")
+}
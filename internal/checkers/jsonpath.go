@@ -0,0 +1,77 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupJsonPath resolves a simple JSONPath expression against a decoded JSON document. Only the child operator
+// ('.name' or "['name']") and array indices ('[0]') are supported, e.g. "$.checks[0].status".
+func lookupJsonPath(doc any, path string) (any, error) {
+	tokens, err := parseJsonPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]any:
+			val, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("key '%s' not found", token)
+			}
+			current = val
+		case []any:
+			idx, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not a valid array index", token)
+			}
+			if idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("index %d out of bounds", idx)
+			}
+			current = node[idx]
+		default:
+			return nil, fmt.Errorf("can not descend into '%s'", token)
+		}
+	}
+
+	return current, nil
+}
+
+func parseJsonPath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, errors.New("json path must start with '$'")
+	}
+
+	var tokens []string
+	rest := path[1:]
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in json path '%s'", path)
+			}
+			tokens = append(tokens, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexRune(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' in json path '%s'", path)
+			}
+			tokens = append(tokens, strings.Trim(rest[1:end], `'"`))
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in json path '%s'", rest[0], path)
+		}
+	}
+
+	return tokens, nil
+}
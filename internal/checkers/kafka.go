@@ -2,14 +2,34 @@ package checkers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
 	"go.uber.org/multierr"
 )
 
+const (
+	KafkaCheckerName        = "kafka"
+	kafkaDialTimeout        = 10 * time.Second
+	kafkaReadErrorBackoff   = 30 * time.Second
+	kafkaDefaultMaxMsgBytes = 10e6
+)
+
+// KafkaReader is the part of kafka.Reader that is used by the KafkaChecker.
+type KafkaReader interface {
+	ReadMessage(ctx context.Context) (kafka.Message, error)
+	Close() error
+}
+
+// KafkaChecker consumes a kafka topic in the background and reports an unhealthy state as soon as a message with an
+// accepted key (by default the system's hostname) has been received.
 type KafkaChecker struct {
 	brokers   []string
 	topic     string
@@ -18,15 +38,31 @@ type KafkaChecker struct {
 
 	acceptedKeys []string
 
-	reader *kafka.Reader
+	useTls        bool
+	certFile      string
+	keyFile       string
+	caFile        string
+	saslMechanism sasl.Mechanism
 
-	certFile string
-	keyFile  string
+	reader KafkaReader
+	once   sync.Once
+	mutex  sync.Mutex
+
+	rebootRequested bool
+	readErr         error
 }
 
 type KafkaOpts func(checker *KafkaChecker) error
 
 func NewKafkaChecker(brokers []string, topic string, opts ...KafkaOpts) (*KafkaChecker, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New("no 'brokers' supplied")
+	}
+
+	if len(topic) == 0 {
+		return nil, errors.New("empty 'topic' supplied")
+	}
+
 	c := &KafkaChecker{
 		brokers:      brokers,
 		topic:        topic,
@@ -39,7 +75,21 @@ func NewKafkaChecker(brokers []string, topic string, opts ...KafkaOpts) (*KafkaC
 			errs = multierr.Append(errs, err)
 		}
 	}
-	return c, errs
+	if errs != nil {
+		return nil, errs
+	}
+
+	if len(c.acceptedKeys) == 0 {
+		return nil, errors.New("no accepted keys available")
+	}
+
+	reader, err := c.buildReader()
+	if err != nil {
+		return nil, err
+	}
+	c.reader = reader
+
+	return c, nil
 }
 
 func getDefaultAcceptedKeys() (acceptedKeys []string) {
@@ -53,30 +103,131 @@ func getDefaultAcceptedKeys() (acceptedKeys []string) {
 	return
 }
 
-func (c *KafkaChecker) Start() error {
-	c.reader = kafka.NewReader(kafka.ReaderConfig{
+func (c *KafkaChecker) buildReader() (*kafka.Reader, error) {
+	dialer := &kafka.Dialer{
+		Timeout:       kafkaDialTimeout,
+		DualStack:     true,
+		SASLMechanism: c.saslMechanism,
+	}
+
+	if c.useTls {
+		tlsConfig, err := buildTlsConfig(c.caFile, c.certFile, c.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not build tls config: %w", err)
+		}
+		dialer.TLS = tlsConfig
+	}
+
+	conf := kafka.ReaderConfig{
 		Brokers:   c.brokers,
 		Topic:     c.topic,
 		Partition: c.partition,
-		MaxBytes:  10e6,
 		GroupID:   c.groupId,
-	})
+		MaxBytes:  kafkaDefaultMaxMsgBytes,
+		Dialer:    dialer,
+		// do not act on requests that have been sent before this consumer group existed
+		StartOffset: kafka.LastOffset,
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid kafka reader config: %w", err)
+	}
 
-	return nil
+	reader := kafka.NewReader(conf)
+	if len(c.groupId) == 0 {
+		// without a consumer group, there are no committed offsets. Only consider new requests, otherwise old
+		// requests would trigger a reboot after each start.
+		if err := reader.SetOffset(kafka.LastOffset); err != nil {
+			return nil, fmt.Errorf("could not set offset: %w", err)
+		}
+	}
+
+	return reader, nil
+}
+
+func (c *KafkaChecker) Name() string {
+	return fmt.Sprintf("%s://%s", KafkaCheckerName, c.topic)
+}
+
+// Start runs the consumer in the background until the context is cancelled. If it has not been started explicitly,
+// the first call to IsHealthy starts it for the lifetime of the process.
+func (c *KafkaChecker) Start(ctx context.Context) {
+	c.once.Do(func() {
+		go c.consume(ctx)
+	})
 }
 
-func (c *KafkaChecker) IsHealthy(ctx context.Context) (bool, error) {
+func (c *KafkaChecker) consume(ctx context.Context) {
+	defer func() {
+		if err := c.reader.Close(); err != nil {
+			log.Error().Str("checker", "kafka").Err(err).Msg("could not close kafka reader")
+		}
+	}()
+
 	for {
-		m, err := c.reader.ReadMessage(context.Background())
+		msg, err := c.reader.ReadMessage(ctx)
 		if err != nil {
-			break
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return
+			}
+
+			log.Error().Str("checker", "kafka").Err(err).Msgf("could not read message for checker '%s'", c.Name())
+			c.mutex.Lock()
+			c.readErr = err
+			c.mutex.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(kafkaReadErrorBackoff):
+				continue
+			}
 		}
-		fmt.Printf("message at offset %d: %s = %s\n", m.Offset, string(m.Key), string(m.Value))
+
+		c.handleMessage(msg)
+	}
+}
+
+func (c *KafkaChecker) handleMessage(msg kafka.Message) {
+	key := string(msg.Key)
+	if !c.isAcceptedKey(key) {
+		log.Debug().Str("checker", "kafka").Int64("offset", msg.Offset).Msgf("Ignoring message with key '%s'", key)
+		return
+	}
+
+	log.Info().Str("checker", "kafka").Int64("offset", msg.Offset).Str("value", string(msg.Value)).Msgf("Received reboot request with key '%s'", key)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.rebootRequested = true
+	c.readErr = nil
+}
+
+func (c *KafkaChecker) isAcceptedKey(key string) bool {
+	for _, accepted := range c.acceptedKeys {
+		if key == accepted {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *KafkaChecker) IsHealthy(_ context.Context) (bool, error) {
+	// the ctx of IsHealthy may be scoped to a single check, e.g. by the quorum checker, which must not stop the consumer
+	c.Start(context.Background())
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// a reboot request is never revoked, only the actual reboot recovers from it
+	if c.rebootRequested {
+		return false, nil
 	}
 
-	if err := c.reader.Close(); err != nil {
+	if c.readErr != nil {
+		err := c.readErr
+		c.readErr = nil
 		return false, err
 	}
 
-	return false, nil
+	return true, nil
 }
@@ -1,15 +1,53 @@
 package checkers
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
 
 func UseTLS(certFile, keyFile string) KafkaOpts {
 	return func(c *KafkaChecker) error {
+		c.useTls = true
 		c.certFile = certFile
 		c.keyFile = keyFile
 		return nil
 	}
 }
 
+func KafkaCaFile(caFile string) KafkaOpts {
+	return func(c *KafkaChecker) error {
+		if len(caFile) == 0 {
+			return errors.New("empty CA file provided")
+		}
+
+		c.useTls = true
+		c.caFile = caFile
+		return nil
+	}
+}
+
+// UseSasl enables SASL authentication. Supported mechanisms are 'plain', 'scram-sha-256' and 'scram-sha-512'.
+func UseSasl(mechanism, username, password string) KafkaOpts {
+	return func(c *KafkaChecker) error {
+		var err error
+		switch strings.ToLower(mechanism) {
+		case "plain":
+			c.saslMechanism = plain.Mechanism{Username: username, Password: password}
+		case "scram-sha-256":
+			c.saslMechanism, err = scram.Mechanism(scram.SHA256, username, password)
+		case "scram-sha-512":
+			c.saslMechanism, err = scram.Mechanism(scram.SHA512, username, password)
+		default:
+			return fmt.Errorf("unknown sasl mechanism '%s'", mechanism)
+		}
+		return err
+	}
+}
+
 func AcceptedKeys(keys []string) KafkaOpts {
 	return func(c *KafkaChecker) error {
 		if len(keys) == 0 {
@@ -19,3 +57,80 @@ func AcceptedKeys(keys []string) KafkaOpts {
 		return nil
 	}
 }
+
+func KafkaGroupId(groupId string) KafkaOpts {
+	return func(c *KafkaChecker) error {
+		c.groupId = groupId
+		return nil
+	}
+}
+
+func KafkaPartition(partition int) KafkaOpts {
+	return func(c *KafkaChecker) error {
+		if partition < 0 {
+			return errors.New("partition must not be negative")
+		}
+		c.partition = partition
+		return nil
+	}
+}
+
+//nolint:cyclop
+func KafkaCheckerFromMap(args map[string]any) (*KafkaChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build kafka checker, empty args supplied")
+	}
+
+	brokers, ok, err := stringSliceFromArgs(args, "brokers")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("could not build kafka checker, no 'brokers' supplied")
+	}
+
+	topic, ok := args["topic"].(string)
+	if !ok {
+		return nil, errors.New("could not build kafka checker, no 'topic' supplied")
+	}
+
+	var opts []KafkaOpts
+	if groupId, ok := args["group_id"].(string); ok {
+		opts = append(opts, KafkaGroupId(groupId))
+	}
+
+	partition, ok, err := intFromArgs(args, "partition")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, KafkaPartition(partition))
+	}
+
+	acceptedKeys, ok, err := stringSliceFromArgs(args, "accepted_keys")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, AcceptedKeys(acceptedKeys))
+	}
+
+	clientCert, okCert := args["tls_client_cert"].(string)
+	clientKey, okKey := args["tls_client_key"].(string)
+	useTls, _ := args["tls"].(bool)
+	if useTls || okCert || okKey {
+		opts = append(opts, UseTLS(clientCert, clientKey))
+	}
+
+	if caFile, ok := args["tls_ca"].(string); ok {
+		opts = append(opts, KafkaCaFile(caFile))
+	}
+
+	if mechanism, ok := args["sasl_mechanism"].(string); ok {
+		username, _ := args["sasl_username"].(string)
+		password, _ := args["sasl_password"].(string)
+		opts = append(opts, UseSasl(mechanism, username, password))
+	}
+
+	return NewKafkaChecker(brokers, topic, opts...)
+}
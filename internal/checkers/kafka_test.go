@@ -0,0 +1,208 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type kafkaReaderDummy struct {
+	messages chan kafka.Message
+	errs     chan error
+	closed   chan struct{}
+}
+
+func newKafkaReaderDummy() *kafkaReaderDummy {
+	return &kafkaReaderDummy{
+		messages: make(chan kafka.Message),
+		errs:     make(chan error),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (k *kafkaReaderDummy) ReadMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-k.messages:
+		return msg, nil
+	case err := <-k.errs:
+		return kafka.Message{}, err
+	case <-k.closed:
+		return kafka.Message{}, io.EOF
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (k *kafkaReaderDummy) Close() error {
+	return nil
+}
+
+func waitForKafkaChecker(t *testing.T, c *KafkaChecker, want bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		got, _ := c.IsHealthy(context.Background())
+		if got == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("IsHealthy() did not return %v in time", want)
+}
+
+func TestKafkaChecker_IsHealthy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := newKafkaReaderDummy()
+	c := &KafkaChecker{
+		topic:        "reboot",
+		acceptedKeys: []string{"my-host"},
+		reader:       reader,
+	}
+	c.Start(ctx)
+
+	got, err := c.IsHealthy(ctx)
+	if err != nil || !got {
+		t.Fatalf("IsHealthy() got = %v, err = %v, want true", got, err)
+	}
+
+	reader.messages <- kafka.Message{Key: []byte("other-host")}
+	reader.errs <- errors.New("broker unavailable")
+	waitForKafkaChecker(t, c, false)
+	_, err = c.IsHealthy(ctx)
+	if err != nil {
+		t.Fatalf("IsHealthy() returned error twice: %v", err)
+	}
+
+	// the reader backs off after an error, feed it directly
+	c.handleMessage(kafka.Message{Key: []byte("other-host")})
+	got, err = c.IsHealthy(ctx)
+	if err != nil || !got {
+		t.Fatalf("IsHealthy() got = %v, err = %v, want true for foreign key", got, err)
+	}
+
+	c.handleMessage(kafka.Message{Key: []byte("my-host")})
+	got, err = c.IsHealthy(ctx)
+	if err != nil || got {
+		t.Fatalf("IsHealthy() got = %v, err = %v, want false after reboot request", got, err)
+	}
+}
+
+func TestKafkaChecker_consume(t *testing.T) {
+	reader := newKafkaReaderDummy()
+	c := &KafkaChecker{
+		topic:        "reboot",
+		acceptedKeys: []string{"my-host", "all"},
+		reader:       reader,
+	}
+
+	waitForKafkaChecker(t, c, true)
+	reader.messages <- kafka.Message{Key: []byte("other-host")}
+	waitForKafkaChecker(t, c, true)
+	reader.messages <- kafka.Message{Key: []byte("all")}
+	waitForKafkaChecker(t, c, false)
+
+	close(reader.closed)
+}
+
+func TestKafkaChecker_IsHealthy_CancelledCtx(t *testing.T) {
+	reader := newKafkaReaderDummy()
+	c := &KafkaChecker{
+		topic:        "reboot",
+		acceptedKeys: []string{"my-host"},
+		reader:       reader,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	got, err := c.IsHealthy(ctx)
+	if err != nil || !got {
+		t.Fatalf("IsHealthy() got = %v, err = %v, want true", got, err)
+	}
+	cancel()
+
+	select {
+	case reader.messages <- kafka.Message{Key: []byte("my-host")}:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("consumer stopped together with the ctx of IsHealthy")
+	}
+	waitForKafkaChecker(t, c, false)
+
+	close(reader.closed)
+}
+
+func TestKafkaCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    map[string]any{},
+			wantErr: true,
+		},
+		{
+			name: "missing topic",
+			args: map[string]any{
+				"brokers": []any{"localhost:9092"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "minimal",
+			args: map[string]any{
+				"brokers": []any{"localhost:9092"},
+				"topic":   "reboot",
+			},
+		},
+		{
+			name: "consumer group with sasl",
+			args: map[string]any{
+				"brokers":        []any{"localhost:9092"},
+				"topic":          "reboot",
+				"group_id":       "conditional-reboot",
+				"accepted_keys":  []any{"my-host"},
+				"tls":            true,
+				"sasl_mechanism": "scram-sha-512",
+				"sasl_username":  "user",
+				"sasl_password":  "pass",
+			},
+		},
+		{
+			name: "partition and group",
+			args: map[string]any{
+				"brokers":   []any{"localhost:9092"},
+				"topic":     "reboot",
+				"group_id":  "conditional-reboot",
+				"partition": 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown sasl mechanism",
+			args: map[string]any{
+				"brokers":        []any{"localhost:9092"},
+				"topic":          "reboot",
+				"sasl_mechanism": "oauth",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KafkaCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KafkaCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				_ = got.reader.Close()
+			}
+		})
+	}
+}
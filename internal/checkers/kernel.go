@@ -0,0 +1,144 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	KernelCheckerName = "kernel"
+	defaultKernelRoot = "/"
+)
+
+// KernelChecker compares the running kernel with the kernels installed under /boot and /lib/modules and reports an
+// unhealthy state if a newer kernel has been installed.
+type KernelChecker struct {
+	root string
+
+	mutex   sync.Mutex
+	running string
+	newest  string
+}
+
+func NewKernelChecker(root string) (*KernelChecker, error) {
+	if len(root) == 0 {
+		return nil, errors.New("empty 'root' provided")
+	}
+
+	return &KernelChecker{root: root}, nil
+}
+
+func KernelCheckerFromMap(args map[string]any) (*KernelChecker, error) {
+	root := defaultKernelRoot
+	if val, ok := args["root"].(string); ok {
+		root = val
+	}
+
+	return NewKernelChecker(root)
+}
+
+func (c *KernelChecker) Name() string {
+	return KernelCheckerName
+}
+
+func (c *KernelChecker) IsHealthy(_ context.Context) (bool, error) {
+	running, err := c.runningKernel()
+	if err != nil {
+		return false, err
+	}
+
+	installed, err := c.installedKernels()
+	if err != nil {
+		return false, err
+	}
+
+	newest := newestKernel(running, installed)
+	if compareKernelVersions(newest, running) <= 0 {
+		return true, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.newest != newest {
+		log.Info().Str("checker", "kernel").Str("running", running).Str("installed", newest).Msg("Newer kernel installed")
+	}
+	c.running = running
+	c.newest = newest
+
+	return false, nil
+}
+
+func (c *KernelChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return fmt.Sprintf("running kernel %s, newer kernel %s installed", c.running, c.newest)
+}
+
+func (c *KernelChecker) runningKernel() (string, error) {
+	data, err := os.ReadFile(filepath.Join(c.root, "proc", "sys", "kernel", "osrelease"))
+	if err != nil {
+		return "", fmt.Errorf("could not determine running kernel: %w", err)
+	}
+
+	release := strings.TrimSpace(string(data))
+	if len(release) == 0 {
+		return "", errors.New("could not determine running kernel: empty release")
+	}
+	return release, nil
+}
+
+// installedKernels returns the releases of all kernel images in /boot and all module directories in /lib/modules.
+// Module directories without 'modules.dep' are ignored, as they are leftovers of uninstalled kernels.
+func (c *KernelChecker) installedKernels() ([]string, error) {
+	var installed []string
+
+	images, err := filepath.Glob(filepath.Join(c.root, "boot", "vmlinuz-*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		installed = append(installed, strings.TrimPrefix(filepath.Base(image), "vmlinuz-"))
+	}
+
+	modules, err := filepath.Glob(filepath.Join(c.root, "lib", "modules", "*", "modules.dep"))
+	if err != nil {
+		return nil, err
+	}
+	for _, module := range modules {
+		installed = append(installed, filepath.Base(filepath.Dir(module)))
+	}
+
+	return installed, nil
+}
+
+// newestKernel returns the newest of the installed kernels with the same flavour as the running kernel. Entries that
+// are not kernel releases, such as rescue images, are ignored.
+func newestKernel(running string, installed []string) string {
+	flavour := kernelFlavour(running)
+
+	newest := running
+	for _, release := range installed {
+		if len(release) == 0 || !unicode.IsDigit(rune(release[0])) || strings.Contains(release, "rescue") {
+			continue
+		}
+
+		if kernelFlavour(release) != flavour {
+			continue
+		}
+
+		if compareKernelVersions(release, newest) > 0 {
+			newest = release
+		}
+	}
+
+	return newest
+}
@@ -0,0 +1,175 @@
+package checkers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_compareKernelVersions(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "6.1.0-13-amd64", b: "6.1.0-13-amd64", want: 0},
+		{a: "6.1.0-13-amd64", b: "6.1.0-9-amd64", want: 1},
+		{a: "6.1.0-9-amd64", b: "6.1.0-13-amd64", want: -1},
+		{a: "6.5.0-14-generic", b: "6.2.0-39-generic", want: 1},
+		{a: "5.14.0-362.8.1.el9_3.x86_64", b: "5.14.0-284.25.1.el9_2.x86_64", want: 1},
+		{a: "5.14.0-284.25.1.el9_2.x86_64", b: "5.14.0-284.30.1.el9_2.x86_64", want: -1},
+		{a: "6.5.10-300.fc39.x86_64", b: "6.5.6-300.fc39.x86_64", want: 1},
+		{a: "6.6.0-0.rc7.56.fc40.x86_64", b: "6.6.0-1.fc40.x86_64", want: -1},
+		{a: "6.6~rc1", b: "6.6", want: -1},
+		{a: "6.6", b: "6.6~rc1", want: 1},
+		{a: "6.6.1", b: "6.6", want: 1},
+		{a: "6.6a", b: "6.6.1", want: -1},
+		{a: "6.06", b: "6.6", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			if got := compareKernelVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareKernelVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newestKernel(t *testing.T) {
+	tests := []struct {
+		name      string
+		running   string
+		installed []string
+		want      string
+	}{
+		{
+			name:      "no kernels installed",
+			running:   "6.1.0-13-amd64",
+			installed: nil,
+			want:      "6.1.0-13-amd64",
+		},
+		{
+			name:      "newer kernel installed",
+			running:   "6.1.0-9-amd64",
+			installed: []string{"6.1.0-9-amd64", "6.1.0-13-amd64"},
+			want:      "6.1.0-13-amd64",
+		},
+		{
+			name:      "newer kernel of other flavour installed",
+			running:   "6.1.0-rpi4-rpi-v8",
+			installed: []string{"6.1.0-rpi4-rpi-v8", "6.1.0-rpi7-rpi-2712"},
+			want:      "6.1.0-rpi4-rpi-v8",
+		},
+		{
+			name:      "rescue image ignored",
+			running:   "6.5.6-300.fc39.x86_64",
+			installed: []string{"0-rescue-2b7a8cbd6f4e4f0a9e41e5d8b0f7c1a2", "6.5.6-300.fc39.x86_64"},
+			want:      "6.5.6-300.fc39.x86_64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newestKernel(tt.running, tt.installed); got != tt.want {
+				t.Errorf("newestKernel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKernelChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name    string
+		running string
+		boot    []string
+		modules []string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "running newest kernel",
+			running: "6.1.0-13-amd64",
+			boot:    []string{"vmlinuz-6.1.0-13-amd64", "vmlinuz-6.1.0-9-amd64"},
+			modules: []string{"6.1.0-13-amd64", "6.1.0-9-amd64"},
+			want:    true,
+		},
+		{
+			name:    "newer kernel in /boot",
+			running: "6.1.0-9-amd64",
+			boot:    []string{"vmlinuz-6.1.0-13-amd64", "vmlinuz-6.1.0-9-amd64"},
+			want:    false,
+		},
+		{
+			name:    "newer kernel in /lib/modules",
+			running: "5.14.0-284.25.1.el9_2.x86_64",
+			modules: []string{"5.14.0-284.25.1.el9_2.x86_64", "5.14.0-362.8.1.el9_3.x86_64"},
+			want:    false,
+		},
+		{
+			name:    "kernels of other flavours with same version installed",
+			running: "6.1.0-13-amd64",
+			boot:    []string{"vmlinuz-6.1.0-13-amd64", "vmlinuz-6.1.0-13-cloud-amd64", "vmlinuz-6.1.0-13-rt-amd64"},
+			modules: []string{"6.1.0-13-amd64", "6.1.0-13-cloud-amd64", "6.1.0-13-rt-amd64"},
+			want:    true,
+		},
+		{
+			name:    "newer kernel of same multi-part flavour installed",
+			running: "6.1.0-9-rt-amd64",
+			boot:    []string{"vmlinuz-6.1.0-9-rt-amd64", "vmlinuz-6.1.0-13-amd64", "vmlinuz-6.1.0-13-rt-amd64"},
+			want:    false,
+		},
+		{
+			name:    "debug kernel of same version installed",
+			running: "5.14.0-362.el9.x86_64",
+			boot:    []string{"vmlinuz-5.14.0-362.el9.x86_64", "vmlinuz-5.14.0-362.el9.x86_64+debug"},
+			modules: []string{"5.14.0-362.el9.x86_64", "5.14.0-362.el9.x86_64+debug"},
+			want:    true,
+		},
+		{
+			name:    "newer debug kernel installed",
+			running: "5.14.0-284.el9.x86_64+debug",
+			boot:    []string{"vmlinuz-5.14.0-284.el9.x86_64+debug", "vmlinuz-5.14.0-362.el9.x86_64+debug"},
+			want:    false,
+		},
+		{
+			name:    "running kernel unknown",
+			running: "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFixture(t, filepath.Join(root, "proc", "sys", "kernel", "osrelease"), tt.running+"\n")
+			for _, image := range tt.boot {
+				writeFixture(t, filepath.Join(root, "boot", image), "")
+			}
+			for _, module := range tt.modules {
+				writeFixture(t, filepath.Join(root, "lib", "modules", module, "modules.dep"), "")
+			}
+
+			c, err := NewKernelChecker(root)
+			if err != nil {
+				t.Fatalf("NewKernelChecker() error = %v", err)
+			}
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeFixture(t *testing.T, file, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,111 @@
+package checkers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// compareKernelVersions compares two kernel releases the same way rpm's rpmvercmp does, which also yields the
+// expected results for dpkg-style kernel releases: the strings are split into alternating numeric and alphabetic
+// segments, numeric segments are compared numerically and considered newer than alphabetic ones, and a tilde sorts
+// before anything else, even the end of the string. It returns 1 if a is newer than b, -1 if b is newer than a and
+// 0 if both are equal.
+//
+//nolint:cyclop
+func compareKernelVersions(a, b string) int {
+	for {
+		a = strings.TrimLeftFunc(a, isVersionSeparator)
+		b = strings.TrimLeftFunc(b, isVersionSeparator)
+
+		aTilde := strings.HasPrefix(a, "~")
+		bTilde := strings.HasPrefix(b, "~")
+		if aTilde || bTilde {
+			if !aTilde {
+				return 1
+			}
+			if !bTilde {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		if len(a) == 0 || len(b) == 0 {
+			break
+		}
+
+		isNum := unicode.IsDigit(rune(a[0]))
+		var segA, segB string
+		if isNum {
+			segA, a = splitVersionSegment(a, unicode.IsDigit)
+			segB, b = splitVersionSegment(b, unicode.IsDigit)
+		} else {
+			segA, a = splitVersionSegment(a, unicode.IsLetter)
+			segB, b = splitVersionSegment(b, unicode.IsLetter)
+		}
+
+		// segments of different types, numeric segments are newer
+		if len(segB) == 0 {
+			if isNum {
+				return 1
+			}
+			return -1
+		}
+
+		if isNum {
+			segA = strings.TrimLeft(segA, "0")
+			segB = strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				if len(segA) > len(segB) {
+					return 1
+				}
+				return -1
+			}
+		}
+
+		if cmp := strings.Compare(segA, segB); cmp != 0 {
+			return cmp
+		}
+	}
+
+	// whichever version has characters left over wins
+	if len(a) == len(b) {
+		return 0
+	}
+	if len(a) > 0 {
+		return 1
+	}
+	return -1
+}
+
+func isVersionSeparator(r rune) bool {
+	return r != '~' && !unicode.IsDigit(r) && !unicode.IsLetter(r)
+}
+
+func splitVersionSegment(s string, pred func(rune) bool) (string, string) {
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return !pred(r)
+	})
+	if idx < 0 {
+		return s, ""
+	}
+	return s[:idx], s[idx:]
+}
+
+// kernelFlavour returns the flavour of a kernel release, so kernels of different flavours are not compared with each
+// other. For Debian-style releases, that's everything after the ABI segment, e.g. 'amd64' for '6.1.0-13-amd64' and
+// 'rt-amd64' for '6.1.0-13-rt-amd64', for RHEL-style releases the variant suffix, e.g. '+debug' for
+// '5.14.0-362.el9.x86_64+debug'. Releases without flavour yield an empty string.
+func kernelFlavour(release string) string {
+	var variant string
+	if idx := strings.IndexByte(release, '+'); idx >= 0 {
+		release, variant = release[:idx], release[idx:]
+	}
+
+	segments := strings.SplitN(release, "-", 3)
+	if len(segments) < 3 || len(segments[2]) == 0 || !unicode.IsLetter(rune(segments[2][0])) {
+		return variant
+	}
+
+	return segments[2] + variant
+}
@@ -0,0 +1,257 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/conditional-reboot/internal/uptime"
+	"go.uber.org/multierr"
+)
+
+const (
+	KmsgCheckerName     = "kmsg"
+	defaultKmsgFile     = "/dev/kmsg"
+	defaultKmsgWindow   = 15 * time.Minute
+	kmsgPollInterval    = 1 * time.Second
+	kmsgReopenInterval  = 30 * time.Second
+	kmsgMaxMatchesKept  = 1000
+	kmsgReaderBufferLen = 8192
+)
+
+var defaultKmsgPatterns = []string{
+	`NETDEV WATCHDOG`,
+	`soft lockup`,
+	`hung_task`,
+	`Call Trace`,
+}
+
+type kmsgMatch struct {
+	time time.Time
+	line string
+}
+
+// KmsgChecker follows the kernel log and reports an unhealthy state if messages matching the configured patterns,
+// e.g. driver hangs, have been logged within a sliding window.
+type KmsgChecker struct {
+	file       string
+	patterns   []*regexp.Regexp
+	window     time.Duration
+	minMatches int
+
+	// bootTime is used to convert the timestamps of /dev/kmsg records, which are relative to boot
+	bootTime time.Time
+
+	once    sync.Once
+	mutex   sync.Mutex
+	matches []kmsgMatch
+	readErr error
+}
+
+type KmsgOpts func(checker *KmsgChecker) error
+
+func NewKmsgChecker(opts ...KmsgOpts) (*KmsgChecker, error) {
+	checker := &KmsgChecker{
+		file:       defaultKmsgFile,
+		window:     defaultKmsgWindow,
+		minMatches: 1,
+	}
+
+	systemUptime, err := uptime.Uptime()
+	if err == nil {
+		checker.bootTime = time.Now().Add(-systemUptime)
+	}
+
+	var errs error
+	if err := KmsgPatterns(defaultKmsgPatterns)(checker); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return checker, errs
+}
+
+func (c *KmsgChecker) Name() string {
+	return fmt.Sprintf("%s://%s", KmsgCheckerName, c.file)
+}
+
+// Start follows the kernel log in the background until the context is cancelled. If it has not been started
+// explicitly, the first call to IsHealthy starts it for the lifetime of the process.
+func (c *KmsgChecker) Start(ctx context.Context) {
+	c.once.Do(func() {
+		go c.follow(ctx)
+	})
+}
+
+func (c *KmsgChecker) follow(ctx context.Context) {
+	for {
+		err := c.readFile(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error().Str("checker", "kmsg").Err(err).Msgf("could not read '%s'", c.file)
+		c.mutex.Lock()
+		c.readErr = err
+		c.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(kmsgReopenInterval):
+		}
+	}
+}
+
+//nolint:cyclop
+func (c *KmsgChecker) readFile(ctx context.Context) error {
+	file, err := os.Open(c.file)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// unblock pending reads
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		_ = file.Close()
+	}()
+
+	c.mutex.Lock()
+	c.readErr = nil
+	c.mutex.Unlock()
+
+	reader := bufio.NewReaderSize(file, kmsgReaderBufferLen)
+	var pending string
+	for {
+		line, err := reader.ReadString('\n')
+		pending += line
+		if err == nil {
+			c.handleLine(strings.TrimRight(pending, "\n"))
+			pending = ""
+			continue
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.Is(err, io.EOF):
+			// regular files do not block at their end, wait for more lines to be appended
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(kmsgPollInterval):
+			}
+		case errors.Is(err, syscall.EPIPE):
+			// records have been overwritten in the ring buffer before we read them
+			pending = ""
+		default:
+			return err
+		}
+	}
+}
+
+func (c *KmsgChecker) handleLine(line string) {
+	timestamp, msg, ok := parseKmsgLine(line, c.bootTime)
+	if !ok {
+		return
+	}
+
+	for _, pattern := range c.patterns {
+		if !pattern.MatchString(msg) {
+			continue
+		}
+
+		log.Warn().Str("checker", "kmsg").Time("logged", timestamp).Msgf("Kernel log matches pattern '%s': %s", pattern.String(), msg)
+		c.mutex.Lock()
+		c.matches = append(c.matches, kmsgMatch{time: timestamp, line: msg})
+		c.pruneMatches(time.Now())
+		c.mutex.Unlock()
+		return
+	}
+}
+
+// parseKmsgLine extracts the message and its timestamp of a /dev/kmsg record ('priority,sequence,usec,flags;message').
+// Lines in other formats are used verbatim and are timestamped with the current time. Continuation lines are
+// skipped.
+func parseKmsgLine(line string, bootTime time.Time) (time.Time, string, bool) {
+	if len(line) == 0 || strings.HasPrefix(line, " ") {
+		return time.Time{}, "", false
+	}
+
+	prefix, msg, found := strings.Cut(line, ";")
+	if !found || bootTime.IsZero() {
+		return time.Now(), line, true
+	}
+
+	fields := strings.Split(prefix, ",")
+	if len(fields) < 3 {
+		return time.Now(), line, true
+	}
+
+	usec, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return time.Now(), line, true
+	}
+
+	return bootTime.Add(time.Duration(usec) * time.Microsecond), msg, true
+}
+
+// pruneMatches removes matches that are outside the window. The caller must hold the lock.
+func (c *KmsgChecker) pruneMatches(now time.Time) {
+	cutoff := now.Add(-c.window)
+	kept := c.matches[:0]
+	for _, match := range c.matches {
+		if match.time.After(cutoff) {
+			kept = append(kept, match)
+		}
+	}
+
+	if len(kept) > kmsgMaxMatchesKept {
+		kept = kept[len(kept)-kmsgMaxMatchesKept:]
+	}
+	c.matches = kept
+}
+
+func (c *KmsgChecker) IsHealthy(_ context.Context) (bool, error) {
+	// the ctx of IsHealthy may be scoped to a single check, e.g. by the quorum checker, which must not stop following
+	c.Start(context.Background())
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.readErr != nil {
+		return false, c.readErr
+	}
+
+	c.pruneMatches(time.Now())
+	return len(c.matches) < c.minMatches, nil
+}
+
+func (c *KmsgChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(c.matches) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d matching kernel messages within %s, last: %s", len(c.matches), c.window, c.matches[len(c.matches)-1].line)
+}
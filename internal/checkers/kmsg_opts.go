@@ -0,0 +1,95 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+func KmsgFile(file string) KmsgOpts {
+	return func(checker *KmsgChecker) error {
+		if len(file) == 0 {
+			return errors.New("empty file provided")
+		}
+
+		checker.file = file
+		return nil
+	}
+}
+
+func KmsgPatterns(patterns []string) KmsgOpts {
+	return func(checker *KmsgChecker) error {
+		if len(patterns) == 0 {
+			return errors.New("no patterns provided")
+		}
+
+		compiled := make([]*regexp.Regexp, 0, len(patterns))
+		for _, pattern := range patterns {
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("could not compile pattern '%s': %w", pattern, err)
+			}
+			compiled = append(compiled, regex)
+		}
+
+		checker.patterns = compiled
+		return nil
+	}
+}
+
+func KmsgWindow(window time.Duration) KmsgOpts {
+	return func(checker *KmsgChecker) error {
+		if window <= 0 {
+			return errors.New("window must be > 0")
+		}
+
+		checker.window = window
+		return nil
+	}
+}
+
+// KmsgMinMatches sets the number of matching messages within the window that are needed to report an unhealthy state.
+func KmsgMinMatches(minMatches int) KmsgOpts {
+	return func(checker *KmsgChecker) error {
+		if minMatches < 1 {
+			return errors.New("min matches must be >= 1")
+		}
+
+		checker.minMatches = minMatches
+		return nil
+	}
+}
+
+func KmsgCheckerFromMap(args map[string]any) (*KmsgChecker, error) {
+	var opts []KmsgOpts
+	if file, ok := args["file"].(string); ok {
+		opts = append(opts, KmsgFile(file))
+	}
+
+	patterns, ok, err := stringSliceFromArgs(args, "patterns")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, KmsgPatterns(patterns))
+	}
+
+	window, ok, err := durationFromArgs(args, "window")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, KmsgWindow(window))
+	}
+
+	minMatches, ok, err := intFromArgs(args, "min_matches")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, KmsgMinMatches(minMatches))
+	}
+
+	return NewKmsgChecker(opts...)
+}
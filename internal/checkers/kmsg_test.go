@@ -0,0 +1,150 @@
+package checkers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_parseKmsgLine(t *testing.T) {
+	bootTime := time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		line     string
+		wantTime time.Time
+		wantMsg  string
+		wantOk   bool
+	}{
+		{
+			name:     "kmsg record",
+			line:     "4,1234,90000000,-;NETDEV WATCHDOG: enp1s0 (igc): transmit queue 0 timed out",
+			wantTime: bootTime.Add(90 * time.Second),
+			wantMsg:  "NETDEV WATCHDOG: enp1s0 (igc): transmit queue 0 timed out",
+			wantOk:   true,
+		},
+		{
+			name:   "continuation line",
+			line:   " SUBSYSTEM=net",
+			wantOk: false,
+		},
+		{
+			name:   "empty line",
+			line:   "",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTime, gotMsg, gotOk := parseKmsgLine(tt.line, bootTime)
+			if gotOk != tt.wantOk {
+				t.Fatalf("parseKmsgLine() ok = %v, want %v", gotOk, tt.wantOk)
+			}
+			if !gotOk {
+				return
+			}
+			if !gotTime.Equal(tt.wantTime) {
+				t.Errorf("parseKmsgLine() time = %v, want %v", gotTime, tt.wantTime)
+			}
+			if gotMsg != tt.wantMsg {
+				t.Errorf("parseKmsgLine() msg = %v, want %v", gotMsg, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestKmsgChecker_IsHealthy(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "kmsg")
+	// the hang has been logged an hour ago, outside the window
+	bootTime := time.Now().Add(-2 * time.Hour)
+	old := "3,100,3600000000,-;watchdog: BUG: soft lockup - CPU#0 stuck for 22s! [kworker/0:1:42]\n"
+	writeFixture(t, file, "6,99,1000,-;Linux version 6.1.0-13-amd64\n"+old)
+
+	c, err := NewKmsgChecker(KmsgFile(file), KmsgWindow(10*time.Minute))
+	if err != nil {
+		t.Fatalf("NewKmsgChecker() error = %v", err)
+	}
+	c.bootTime = bootTime
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.Start(ctx)
+
+	waitForKmsgChecker(t, c, true)
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("some unrelated message\n")
+	_, _ = f.WriteString("NETDEV WATCHDOG: enp1s0 (igc): transmit queue 0 timed out\n")
+	_ = f.Close()
+
+	waitForKmsgChecker(t, c, false)
+	if got := c.Reason(); len(got) == 0 {
+		t.Errorf("Reason() is empty")
+	}
+}
+
+func TestKmsgChecker_IsHealthy_CancelledCtx(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "kmsg")
+	writeFixture(t, file, "6,99,1000,-;Linux version 6.1.0-13-amd64\n")
+
+	c, err := NewKmsgChecker(KmsgFile(file), KmsgWindow(10*time.Minute))
+	if err != nil {
+		t.Fatalf("NewKmsgChecker() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	got, err := c.IsHealthy(ctx)
+	if err != nil || !got {
+		t.Fatalf("IsHealthy() got = %v, err = %v, want true", got, err)
+	}
+	cancel()
+
+	// give a follower bound to the cancelled ctx the chance to stop before the line is appended
+	time.Sleep(50 * time.Millisecond)
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("NETDEV WATCHDOG: enp1s0 (igc): transmit queue 0 timed out\n")
+	_ = f.Close()
+
+	waitForKmsgChecker(t, c, false)
+}
+
+func TestKmsgChecker_IsHealthy_MissingFile(t *testing.T) {
+	c, err := NewKmsgChecker(KmsgFile(filepath.Join(t.TempDir(), "missing")))
+	if err != nil {
+		t.Fatalf("NewKmsgChecker() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.Start(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := c.IsHealthy(ctx); err != nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("IsHealthy() did not return error for missing file")
+}
+
+func waitForKmsgChecker(t *testing.T, c *KmsgChecker, want bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		got, err := c.IsHealthy(context.Background())
+		if err == nil && got == want {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("IsHealthy() did not return %v in time", want)
+}
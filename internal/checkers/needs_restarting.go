@@ -0,0 +1,160 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	NeedsRestartingCheckerName = "needs_restarting"
+
+	needsRestartingExitOk           = 0
+	needsRestartingExitRebootNeeded = 1
+)
+
+// NeedsRestarting runs 'needs-restarting -r' and returns its exit code and output.
+type NeedsRestarting interface {
+	Result(ctx context.Context) (int, string, error)
+}
+
+type NeedsRestartingCmd struct {
+	command []string
+}
+
+func (n *NeedsRestartingCmd) Result(ctx context.Context) (int, string, error) {
+	command := n.command
+	if len(command) == 0 {
+		command = detectNeedsRestartingCmd()
+	}
+
+	// #nosec G204
+	out, err := exec.CommandContext(ctx, command[0], command[1:]...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), string(out), nil
+		}
+		return -1, "", fmt.Errorf("could not determine if reboot is needed: %w", err)
+	}
+
+	return needsRestartingExitOk, string(out), nil
+}
+
+// detectNeedsRestartingCmd prefers the dnf plugin and falls back to the binary shipped with yum-utils.
+func detectNeedsRestartingCmd() []string {
+	if _, err := exec.LookPath("dnf"); err == nil {
+		return []string{"dnf", "needs-restarting", "-r"}
+	}
+
+	return []string{"needs-restarting", "-r"}
+}
+
+// NeedsRestartingChecker uses 'needs-restarting -r' of dnf-plugins-core or yum-utils to check whether rebooting is
+// needed on RHEL-family systems.
+type NeedsRestartingChecker struct {
+	rebootNeeded bool
+	packages     []string
+
+	sync            sync.Mutex
+	needsRestarting NeedsRestarting
+}
+
+type NeedsRestartingOpts func(checker *NeedsRestartingChecker) error
+
+func NewNeedsRestartingChecker(options ...NeedsRestartingOpts) (*NeedsRestartingChecker, error) {
+	checker := &NeedsRestartingChecker{
+		sync:            sync.Mutex{},
+		needsRestarting: &NeedsRestartingCmd{},
+	}
+
+	var errs error
+	for _, opt := range options {
+		err := opt(checker)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return checker, errs
+}
+
+func (n *NeedsRestartingChecker) Name() string {
+	return NeedsRestartingCheckerName
+}
+
+func (n *NeedsRestartingChecker) IsHealthy(ctx context.Context) (bool, error) {
+	n.sync.Lock()
+	defer n.sync.Unlock()
+
+	// use cached reply
+	if n.rebootNeeded {
+		return false, nil
+	}
+
+	exitCode, out, err := n.needsRestarting.Result(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rebootNeeded, err := n.evaluate(exitCode, out)
+	if err != nil {
+		return false, err
+	}
+
+	// cache a response in case we need to reboot - we won't recover from a needed reboot until we actually reboot
+	n.rebootNeeded = rebootNeeded
+	return !rebootNeeded, nil
+}
+
+func (n *NeedsRestartingChecker) Reason() string {
+	n.sync.Lock()
+	defer n.sync.Unlock()
+
+	if !n.rebootNeeded {
+		return ""
+	}
+	if len(n.packages) == 0 {
+		return "reboot required"
+	}
+	return fmt.Sprintf("reboot required by updated packages %s", strings.Join(n.packages, ", "))
+}
+
+func (n *NeedsRestartingChecker) evaluate(exitCode int, out string) (bool, error) {
+	switch exitCode {
+	case needsRestartingExitOk:
+		// older versions of needs-restarting do not reflect the result in the exit code
+		if !strings.Contains(out, "Reboot is required") {
+			return false, nil
+		}
+	case needsRestartingExitRebootNeeded:
+	default:
+		return false, fmt.Errorf("needs-restarting exited with unexpected code %d: %s", exitCode, strings.TrimSpace(out))
+	}
+
+	n.packages = parseNeedsRestartingPackages(out)
+	log.Info().Str("checker", "needs_restarting").Strs("packages", n.packages).Msg("Reboot required")
+	return true, nil
+}
+
+// parseNeedsRestartingPackages extracts the packages listed as ' * <package>' from the output of 'needs-restarting -r'
+func parseNeedsRestartingPackages(out string) []string {
+	packages := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "* ") {
+			packages = append(packages, strings.TrimSpace(strings.TrimPrefix(line, "* ")))
+		}
+	}
+
+	return packages
+}
@@ -0,0 +1,32 @@
+package checkers
+
+import "errors"
+
+// SetNeedsRestartingCommand overrides the auto-detected command, e.g. '["yum", "needs-restarting", "-r"]'.
+func SetNeedsRestartingCommand(command []string) NeedsRestartingOpts {
+	return func(checker *NeedsRestartingChecker) error {
+		if len(command) == 0 {
+			return errors.New("empty command provided")
+		}
+
+		checker.needsRestarting = &NeedsRestartingCmd{command: command}
+		return nil
+	}
+}
+
+func NeedsRestartingCheckerFromMap(args map[string]any) (*NeedsRestartingChecker, error) {
+	if args == nil {
+		return NewNeedsRestartingChecker()
+	}
+
+	var opts []NeedsRestartingOpts
+	command, ok, err := stringSliceFromArgs(args, "command")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, SetNeedsRestartingCommand(command))
+	}
+
+	return NewNeedsRestartingChecker(opts...)
+}
@@ -0,0 +1,127 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const needsRestartingRebootOutput = `Core libraries or services have been updated since boot-up:
+  * kernel
+  * systemd
+
+Reboot is required to fully utilize these updates.
+More information: https://access.redhat.com/solutions/27943`
+
+type needsRestartingDummy struct {
+	exitCode int
+	out      string
+	err      error
+}
+
+func (n *needsRestartingDummy) Result(_ context.Context) (int, string, error) {
+	return n.exitCode, n.out, n.err
+}
+
+func TestNeedsRestartingChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name            string
+		needsRestarting NeedsRestarting
+		want            bool
+		wantPackages    []string
+		wantReason      string
+		wantErr         bool
+	}{
+		{
+			name: "no reboot needed",
+			needsRestarting: &needsRestartingDummy{
+				exitCode: 0,
+				out: `No core libraries or services have been updated since boot-up.
+Reboot should not be necessary.`,
+			},
+			want: true,
+		},
+		{
+			name: "reboot needed",
+			needsRestarting: &needsRestartingDummy{
+				exitCode: 1,
+				out:      needsRestartingRebootOutput,
+			},
+			want:         false,
+			wantPackages: []string{"kernel", "systemd"},
+			wantReason:   "reboot required by updated packages kernel, systemd",
+		},
+		{
+			name: "reboot needed, exit code not reflecting it",
+			needsRestarting: &needsRestartingDummy{
+				exitCode: 0,
+				out:      needsRestartingRebootOutput,
+			},
+			want:         false,
+			wantPackages: []string{"kernel", "systemd"},
+			wantReason:   "reboot required by updated packages kernel, systemd",
+		},
+		{
+			name: "plugin not installed",
+			needsRestarting: &needsRestartingDummy{
+				exitCode: 2,
+				out:      "No such command: needs-restarting.",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "command not found",
+			needsRestarting: &needsRestartingDummy{
+				exitCode: -1,
+				err:      errors.New("executable file not found in $PATH"),
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &NeedsRestartingChecker{
+				sync:            sync.Mutex{},
+				needsRestarting: tt.needsRestarting,
+			}
+			got, err := n.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(n.packages, tt.wantPackages) {
+				t.Errorf("IsHealthy() packages = %v, want %v", n.packages, tt.wantPackages)
+			}
+			if n.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", n.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestNeedsRestartingChecker_IsHealthy_Cached(t *testing.T) {
+	dummy := &needsRestartingDummy{
+		exitCode: 1,
+		out:      needsRestartingRebootOutput,
+	}
+	n, _ := NewNeedsRestartingChecker()
+	n.needsRestarting = dummy
+
+	if got, _ := n.IsHealthy(context.Background()); got {
+		t.Fatalf("IsHealthy() got = %v, want false", got)
+	}
+
+	// a needed reboot is only resolved by actually rebooting
+	dummy.exitCode = 0
+	dummy.out = ""
+	if got, _ := n.IsHealthy(context.Background()); got {
+		t.Fatalf("IsHealthy() got = %v, want cached false", got)
+	}
+}
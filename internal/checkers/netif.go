@@ -0,0 +1,191 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	NetworkInterfaceCheckerName = "netif"
+	defaultSysfsRoot            = "/sys"
+)
+
+var defaultNetifOperStates = []string{"up"}
+
+type netifCounters struct {
+	rx uint64
+	tx uint64
+}
+
+// NetworkInterfaceChecker checks the state of network interfaces using sysfs. Besides carrier and operational
+// state, it detects RX/TX byte counters that do not change between two checks, which indicates a driver that
+// stopped moving packets while the link is still up.
+type NetworkInterfaceChecker struct {
+	interfaces   []string
+	sysfsRoot    string
+	operStates   []string
+	checkCarrier bool
+	checkRx      bool
+	checkTx      bool
+
+	mutex    sync.Mutex
+	counters map[string]netifCounters
+	problems []string
+}
+
+type NetworkInterfaceOpts func(checker *NetworkInterfaceChecker) error
+
+func NewNetworkInterfaceChecker(interfaces []string, opts ...NetworkInterfaceOpts) (*NetworkInterfaceChecker, error) {
+	if len(interfaces) == 0 {
+		return nil, errors.New("no 'interfaces' provided")
+	}
+
+	checker := &NetworkInterfaceChecker{
+		interfaces:   interfaces,
+		sysfsRoot:    defaultSysfsRoot,
+		operStates:   defaultNetifOperStates,
+		checkCarrier: true,
+		checkRx:      true,
+		checkTx:      true,
+		counters:     map[string]netifCounters{},
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return checker, errs
+}
+
+func (c *NetworkInterfaceChecker) Name() string {
+	return fmt.Sprintf("%s://%s", NetworkInterfaceCheckerName, strings.Join(c.interfaces, ","))
+}
+
+func (c *NetworkInterfaceChecker) IsHealthy(_ context.Context) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var problems []string
+	for _, iface := range c.interfaces {
+		problems = append(problems, c.checkInterface(iface)...)
+	}
+
+	c.problems = problems
+	if len(problems) > 0 {
+		log.Warn().Str("checker", "netif").Strs("problems", problems).Msgf("Checker '%s' detected problems", c.Name())
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (c *NetworkInterfaceChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return strings.Join(c.problems, ", ")
+}
+
+// checkInterface returns the problems detected for the given interface. The caller must hold the lock.
+func (c *NetworkInterfaceChecker) checkInterface(iface string) []string {
+	dir := filepath.Join(c.sysfsRoot, "class", "net", iface)
+	if _, err := os.Stat(dir); err != nil {
+		delete(c.counters, iface)
+		return []string{fmt.Sprintf("%s: interface not found", iface)}
+	}
+
+	var problems []string
+	operState, err := readSysfsString(filepath.Join(dir, "operstate"))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%s: could not read operstate", iface))
+	} else if !c.isAcceptedOperState(operState) {
+		problems = append(problems, fmt.Sprintf("%s: operstate is %s", iface, operState))
+	}
+
+	if c.checkCarrier {
+		// reading the carrier of an interface that is down yields an error
+		carrier, err := readSysfsString(filepath.Join(dir, "carrier"))
+		if err != nil || carrier != "1" {
+			problems = append(problems, fmt.Sprintf("%s: no carrier", iface))
+		}
+	}
+
+	if !c.checkRx && !c.checkTx {
+		return problems
+	}
+
+	current, err := readNetifCounters(dir)
+	if err != nil {
+		delete(c.counters, iface)
+		return append(problems, fmt.Sprintf("%s: could not read statistics", iface))
+	}
+
+	previous, found := c.counters[iface]
+	c.counters[iface] = current
+	if !found {
+		return problems
+	}
+
+	if c.checkRx && current.rx == previous.rx {
+		problems = append(problems, fmt.Sprintf("%s: rx_bytes frozen at %d", iface, current.rx))
+	}
+	if c.checkTx && current.tx == previous.tx {
+		problems = append(problems, fmt.Sprintf("%s: tx_bytes frozen at %d", iface, current.tx))
+	}
+
+	return problems
+}
+
+func (c *NetworkInterfaceChecker) isAcceptedOperState(operState string) bool {
+	for _, accepted := range c.operStates {
+		if strings.EqualFold(operState, accepted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func readNetifCounters(dir string) (netifCounters, error) {
+	rx, err := readSysfsUint(filepath.Join(dir, "statistics", "rx_bytes"))
+	if err != nil {
+		return netifCounters{}, err
+	}
+
+	tx, err := readSysfsUint(filepath.Join(dir, "statistics", "tx_bytes"))
+	if err != nil {
+		return netifCounters{}, err
+	}
+
+	return netifCounters{rx: rx, tx: tx}, nil
+}
+
+func readSysfsString(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func readSysfsUint(file string) (uint64, error) {
+	val, err := readSysfsString(file)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(val, 10, 64)
+}
@@ -0,0 +1,87 @@
+package checkers
+
+import "errors"
+
+func NetifSysfsRoot(root string) NetworkInterfaceOpts {
+	return func(checker *NetworkInterfaceChecker) error {
+		if len(root) == 0 {
+			return errors.New("empty sysfs root provided")
+		}
+
+		checker.sysfsRoot = root
+		return nil
+	}
+}
+
+// NetifOperStates sets the accepted operational states. Virtual interfaces, e.g. wireguard, report 'unknown'.
+func NetifOperStates(operStates []string) NetworkInterfaceOpts {
+	return func(checker *NetworkInterfaceChecker) error {
+		if len(operStates) == 0 {
+			return errors.New("no operstates provided")
+		}
+
+		checker.operStates = operStates
+		return nil
+	}
+}
+
+func NetifCheckCarrier(checkCarrier bool) NetworkInterfaceOpts {
+	return func(checker *NetworkInterfaceChecker) error {
+		checker.checkCarrier = checkCarrier
+		return nil
+	}
+}
+
+// NetifDetectFrozenCounters toggles whether unchanged RX or TX byte counters between two checks are reported.
+func NetifDetectFrozenCounters(rx, tx bool) NetworkInterfaceOpts {
+	return func(checker *NetworkInterfaceChecker) error {
+		checker.checkRx = rx
+		checker.checkTx = tx
+		return nil
+	}
+}
+
+func NetworkInterfaceCheckerFromMap(args map[string]any) (*NetworkInterfaceChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build netif checker, empty args supplied")
+	}
+
+	interfaces, ok, err := stringSliceFromArgs(args, "interfaces")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("could not build netif checker, no 'interfaces' supplied")
+	}
+
+	var opts []NetworkInterfaceOpts
+	if root, ok := args["sysfs_root"].(string); ok {
+		opts = append(opts, NetifSysfsRoot(root))
+	}
+
+	operStates, ok, err := stringSliceFromArgs(args, "operstates")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, NetifOperStates(operStates))
+	}
+
+	if checkCarrier, ok := args["check_carrier"].(bool); ok {
+		opts = append(opts, NetifCheckCarrier(checkCarrier))
+	}
+
+	checkRx, okRx := args["check_rx"].(bool)
+	checkTx, okTx := args["check_tx"].(bool)
+	if okRx || okTx {
+		if !okRx {
+			checkRx = true
+		}
+		if !okTx {
+			checkTx = true
+		}
+		opts = append(opts, NetifDetectFrozenCounters(checkRx, checkTx))
+	}
+
+	return NewNetworkInterfaceChecker(interfaces, opts...)
+}
@@ -0,0 +1,114 @@
+package checkers
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+type netifFixture struct {
+	operState string
+	carrier   string
+	rx        uint64
+	tx        uint64
+}
+
+func writeNetifFixture(t *testing.T, root, iface string, fixture netifFixture) {
+	t.Helper()
+	dir := filepath.Join(root, "class", "net", iface)
+	writeFixture(t, filepath.Join(dir, "operstate"), fixture.operState+"\n")
+	writeFixture(t, filepath.Join(dir, "carrier"), fixture.carrier+"\n")
+	writeFixture(t, filepath.Join(dir, "statistics", "rx_bytes"), strconv.FormatUint(fixture.rx, 10)+"\n")
+	writeFixture(t, filepath.Join(dir, "statistics", "tx_bytes"), strconv.FormatUint(fixture.tx, 10)+"\n")
+}
+
+func TestNetworkInterfaceChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []NetworkInterfaceOpts
+		checks   []netifFixture
+		want     []bool
+		wantLast string
+	}{
+		{
+			name: "counters moving",
+			checks: []netifFixture{
+				{operState: "up", carrier: "1", rx: 100, tx: 100},
+				{operState: "up", carrier: "1", rx: 200, tx: 150},
+			},
+			want: []bool{true, true},
+		},
+		{
+			name: "rx frozen",
+			checks: []netifFixture{
+				{operState: "up", carrier: "1", rx: 100, tx: 100},
+				{operState: "up", carrier: "1", rx: 100, tx: 150},
+				{operState: "up", carrier: "1", rx: 120, tx: 200},
+			},
+			want: []bool{true, false, true},
+		},
+		{
+			name: "rx frozen, but only tx checked",
+			opts: []NetworkInterfaceOpts{NetifDetectFrozenCounters(false, true)},
+			checks: []netifFixture{
+				{operState: "up", carrier: "1", rx: 100, tx: 100},
+				{operState: "up", carrier: "1", rx: 100, tx: 150},
+			},
+			want: []bool{true, true},
+		},
+		{
+			name: "no carrier",
+			checks: []netifFixture{
+				{operState: "down", carrier: "0", rx: 100, tx: 100},
+			},
+			want:     []bool{false},
+			wantLast: "eth0: operstate is down, eth0: no carrier",
+		},
+		{
+			name: "operstate unknown accepted",
+			opts: []NetworkInterfaceOpts{NetifOperStates([]string{"up", "unknown"}), NetifCheckCarrier(false)},
+			checks: []netifFixture{
+				{operState: "unknown", rx: 100, tx: 100},
+			},
+			want: []bool{true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			opts := append([]NetworkInterfaceOpts{NetifSysfsRoot(root)}, tt.opts...)
+			c, err := NewNetworkInterfaceChecker([]string{"eth0"}, opts...)
+			if err != nil {
+				t.Fatalf("NewNetworkInterfaceChecker() error = %v", err)
+			}
+
+			for idx, fixture := range tt.checks {
+				writeNetifFixture(t, root, "eth0", fixture)
+				got, err := c.IsHealthy(context.Background())
+				if err != nil {
+					t.Fatalf("IsHealthy() error = %v", err)
+				}
+				if got != tt.want[idx] {
+					t.Errorf("IsHealthy() #%d got = %v, want %v (%s)", idx, got, tt.want[idx], c.Reason())
+				}
+			}
+
+			if len(tt.wantLast) > 0 && c.Reason() != tt.wantLast {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestNetworkInterfaceChecker_IsHealthy_MissingInterface(t *testing.T) {
+	c, err := NewNetworkInterfaceChecker([]string{"wan0"}, NetifSysfsRoot(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewNetworkInterfaceChecker() error = %v", err)
+	}
+
+	got, err := c.IsHealthy(context.Background())
+	if err != nil || got {
+		t.Errorf("IsHealthy() got = %v, err = %v, want false", got, err)
+	}
+}
@@ -0,0 +1,217 @@
+package checkers
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	NtpCheckerName       = "ntp"
+	defaultNtpPort       = "123"
+	defaultNtpMaxOffset  = time.Second
+	defaultNtpTimeout    = 5 * time.Second
+	ntpPacketLen         = 48
+	ntpEpochOffset       = 2208988800
+	ntpModeClient        = 3
+	ntpModeServer        = 4
+	ntpVersion           = 4
+	ntpLeapNotInSync     = 3
+	ntpMaxStratum        = 16
+	ntpOriginTimeOffset  = 24
+	ntpReceiveTimeOffset = 32
+	ntpTransmitOffset    = 40
+)
+
+// NtpChecker sends SNTP queries to the configured servers and reports an unhealthy state if the median offset of the
+// local clock exceeds the configured maximum or if no server answers at all.
+type NtpChecker struct {
+	servers   []string
+	maxOffset time.Duration
+	timeout   time.Duration
+
+	mutex  sync.Mutex
+	reason string
+}
+
+type NtpOpts func(checker *NtpChecker) error
+
+func NewNtpChecker(servers []string, opts ...NtpOpts) (*NtpChecker, error) {
+	if len(servers) == 0 {
+		return nil, errors.New("no 'servers' provided")
+	}
+
+	checker := &NtpChecker{
+		maxOffset: defaultNtpMaxOffset,
+		timeout:   defaultNtpTimeout,
+	}
+
+	for _, server := range servers {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, defaultNtpPort)
+		}
+		checker.servers = append(checker.servers, server)
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return checker, errs
+}
+
+func (c *NtpChecker) Name() string {
+	return fmt.Sprintf("%s://%s", NtpCheckerName, strings.Join(c.servers, ","))
+}
+
+func (c *NtpChecker) IsHealthy(ctx context.Context) (bool, error) {
+	var offsets []time.Duration
+	var errs error
+	for _, server := range c.servers {
+		offset, err := c.queryOffset(ctx, server)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", server, err))
+			continue
+		}
+		log.Debug().Str("checker", "ntp").Msgf("Clock offset to %s is %s", server, offset)
+		offsets = append(offsets, offset)
+	}
+
+	if len(offsets) == 0 {
+		c.setReason("no ntp server answered")
+		log.Warn().Str("checker", "ntp").Err(errs).Msg("No NTP server answered")
+		return false, nil
+	}
+
+	if errs != nil {
+		log.Warn().Str("checker", "ntp").Err(errs).Msg("Not all NTP servers answered")
+	}
+
+	offset := medianDuration(offsets)
+	if time.Duration(math.Abs(float64(offset))) > c.maxOffset {
+		c.setReason(fmt.Sprintf("clock offset %s exceeds %s", offset, c.maxOffset))
+		log.Warn().Str("checker", "ntp").Msgf("Clock offset %s exceeds %s", offset, c.maxOffset)
+		return false, nil
+	}
+
+	c.setReason("")
+	return true, nil
+}
+
+func (c *NtpChecker) setReason(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reason = reason
+}
+
+func (c *NtpChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
+
+// queryOffset sends a single SNTP request (RFC 4330) and returns the offset of the local clock to the server's clock.
+func (c *NtpChecker) queryOffset(ctx context.Context, server string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	request := make([]byte, ntpPacketLen)
+	request[0] = ntpVersion<<3 | ntpModeClient
+	sent := time.Now()
+	transmit := toNtpTime(sent)
+	binary.BigEndian.PutUint64(request[ntpTransmitOffset:], transmit)
+	if _, err := conn.Write(request); err != nil {
+		return 0, err
+	}
+
+	response := make([]byte, ntpPacketLen)
+	for {
+		read, err := conn.Read(response)
+		if err != nil {
+			return 0, err
+		}
+		received := time.Now()
+
+		// ignore stray packets that do not answer our request
+		if read < ntpPacketLen || binary.BigEndian.Uint64(response[ntpOriginTimeOffset:]) != transmit {
+			continue
+		}
+
+		if err := validateNtpResponse(response); err != nil {
+			return 0, err
+		}
+
+		serverReceived := fromNtpTime(binary.BigEndian.Uint64(response[ntpReceiveTimeOffset:]))
+		serverTransmitted := fromNtpTime(binary.BigEndian.Uint64(response[ntpTransmitOffset:]))
+		return (serverReceived.Sub(sent) + serverTransmitted.Sub(received)) / 2, nil
+	}
+}
+
+func validateNtpResponse(response []byte) error {
+	leap := response[0] >> 6
+	mode := response[0] & 0x7
+	stratum := response[1]
+
+	if mode != ntpModeServer {
+		return fmt.Errorf("unexpected mode %d", mode)
+	}
+	if stratum == 0 {
+		return fmt.Errorf("kiss-of-death received: %q", string(response[12:16]))
+	}
+	if leap == ntpLeapNotInSync || stratum >= ntpMaxStratum {
+		return errors.New("server is not synchronized")
+	}
+
+	return nil
+}
+
+func toNtpTime(t time.Time) uint64 {
+	nanos := uint64(t.UnixNano()) + ntpEpochOffset*uint64(time.Second)
+	seconds := nanos / uint64(time.Second)
+	fraction := (nanos % uint64(time.Second)) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+func fromNtpTime(ntp uint64) time.Time {
+	seconds := int64(ntp>>32) - ntpEpochOffset
+	nanos := int64((ntp & 0xffffffff) * uint64(time.Second) >> 32)
+	return time.Unix(seconds, nanos)
+}
+
+func medianDuration(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
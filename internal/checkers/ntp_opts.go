@@ -0,0 +1,61 @@
+package checkers
+
+import (
+	"errors"
+	"time"
+)
+
+func NtpMaxOffset(maxOffset time.Duration) NtpOpts {
+	return func(checker *NtpChecker) error {
+		if maxOffset <= 0 {
+			return errors.New("max offset must be positive")
+		}
+
+		checker.maxOffset = maxOffset
+		return nil
+	}
+}
+
+func NtpTimeout(timeout time.Duration) NtpOpts {
+	return func(checker *NtpChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+func NtpCheckerFromMap(args map[string]any) (*NtpChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build ntp checker, empty args supplied")
+	}
+
+	servers, ok, err := stringSliceFromArgs(args, "servers")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("could not build ntp checker, no 'servers' supplied")
+	}
+
+	var opts []NtpOpts
+	maxOffset, ok, err := durationFromArgs(args, "max_offset")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, NtpMaxOffset(maxOffset))
+	}
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, NtpTimeout(timeout))
+	}
+
+	return NewNtpChecker(servers, opts...)
+}
@@ -0,0 +1,148 @@
+package checkers
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+type ntpServerDummy struct {
+	offset  time.Duration
+	stratum byte
+	leap    byte
+	silent  bool
+}
+
+// startNtpServer answers SNTP requests on a local port with a clock that is shifted by the configured offset.
+func startNtpServer(t *testing.T, server ntpServerDummy) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not start ntp server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, ntpPacketLen)
+		for {
+			read, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if server.silent || read < ntpPacketLen {
+				continue
+			}
+
+			now := toNtpTime(time.Now().Add(server.offset))
+			response := make([]byte, ntpPacketLen)
+			response[0] = server.leap<<6 | ntpVersion<<3 | ntpModeServer
+			response[1] = server.stratum
+			if server.stratum == 0 {
+				copy(response[12:16], "RATE")
+			}
+			copy(response[ntpOriginTimeOffset:], buf[ntpTransmitOffset:ntpPacketLen])
+			binary.BigEndian.PutUint64(response[ntpReceiveTimeOffset:], now)
+			binary.BigEndian.PutUint64(response[ntpTransmitOffset:], now)
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestNtpChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name    string
+		servers []ntpServerDummy
+		want    bool
+	}{
+		{
+			name:    "clock in sync",
+			servers: []ntpServerDummy{{stratum: 2}},
+			want:    true,
+		},
+		{
+			name:    "clock skewed",
+			servers: []ntpServerDummy{{stratum: 2, offset: 10 * time.Second}},
+			want:    false,
+		},
+		{
+			name:    "clock skewed backwards",
+			servers: []ntpServerDummy{{stratum: 2, offset: -10 * time.Second}},
+			want:    false,
+		},
+		{
+			name: "single falseticker",
+			servers: []ntpServerDummy{
+				{stratum: 2},
+				{stratum: 2, offset: time.Hour},
+				{stratum: 3, offset: 10 * time.Millisecond},
+			},
+			want: true,
+		},
+		{
+			name:    "kiss-of-death",
+			servers: []ntpServerDummy{{stratum: 0}},
+			want:    false,
+		},
+		{
+			name:    "server not synchronized",
+			servers: []ntpServerDummy{{stratum: 2, leap: ntpLeapNotInSync}},
+			want:    false,
+		},
+		{
+			name:    "no answer",
+			servers: []ntpServerDummy{{silent: true}},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var servers []string
+			for _, server := range tt.servers {
+				servers = append(servers, startNtpServer(t, server))
+			}
+
+			c, err := NewNtpChecker(servers, NtpTimeout(200*time.Millisecond))
+			if err != nil {
+				t.Fatalf("NewNtpChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
+
+func Test_ntpTime(t *testing.T) {
+	now := time.Now()
+	got := fromNtpTime(toNtpTime(now))
+	if diff := got.Sub(now); diff < -time.Microsecond || diff > time.Microsecond {
+		t.Errorf("fromNtpTime(toNtpTime()) = %v, want %v", got, now)
+	}
+}
+
+func TestNewNtpChecker_DefaultPort(t *testing.T) {
+	c, err := NewNtpChecker([]string{"pool.ntp.org", "::1", "10.0.0.1:1123"})
+	if err != nil {
+		t.Fatalf("NewNtpChecker() error = %v", err)
+	}
+
+	want := []string{"pool.ntp.org:123", "[::1]:123", "10.0.0.1:1123"}
+	for idx := range want {
+		if c.servers[idx] != want[idx] {
+			t.Errorf("servers[%d] = %v, want %v", idx, c.servers[idx], want[idx])
+		}
+	}
+}
@@ -2,14 +2,12 @@ package checkers
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
-	"net/http"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/hashicorp/go-retryablehttp"
 	"github.com/prometheus/client_golang/api"
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
@@ -19,8 +17,10 @@ import (
 
 const PrometheusName = "prometheus"
 
+const defaultPrometheusQueryTimeout = 5 * time.Second
+
 var (
-	// try to re-use clients that use the same address
+	// try to re-use clients that use the same address and settings
 	clients = map[string]v1.API{}
 	mutex   sync.Mutex
 )
@@ -30,9 +30,13 @@ type PrometheusChecker struct {
 	client         v1.API
 	queries        map[string]string
 	address        string
-	clientCertFile string
-	clientKeyFile  string
+	clientSettings httpClientSettings
+	queryTimeout   time.Duration
 	wantResponse   bool
+	expectations   map[string]PrometheusExpectation
+
+	reasonMutex sync.Mutex
+	reason      string
 }
 
 type PrometheusOpts func(checker *PrometheusChecker) error
@@ -50,21 +54,21 @@ func NewPrometheusChecker(name, address string, queries map[string]string, opts
 		return nil, errors.New("empty 'address' supplied")
 	}
 
-	checker := &PrometheusChecker{
-		name:    name,
-		queries: queries,
-		address: address,
-	}
-
-	mutex.Lock()
-	defer mutex.Unlock()
-
-	if _, ok := clients[address]; !ok {
-		client, err := checker.buildClient()
+	rendered := make(map[string]string, len(queries))
+	for queryName, query := range queries {
+		var err error
+		rendered[queryName], err = renderHostTemplate(query)
 		if err != nil {
-			return nil, fmt.Errorf("could not build prometheus client: %w", err)
+			return nil, fmt.Errorf("could not render query '%s': %w", queryName, err)
 		}
-		clients[address] = client
+	}
+
+	checker := &PrometheusChecker{
+		name:         name,
+		queries:      rendered,
+		address:      address,
+		queryTimeout: defaultPrometheusQueryTimeout,
+		expectations: map[string]PrometheusExpectation{},
 	}
 
 	var errs error
@@ -75,39 +79,43 @@ func NewPrometheusChecker(name, address string, queries map[string]string, opts
 		}
 	}
 
-	checker.client = clients[address]
-	return checker, errs
-}
-
-func (c *PrometheusChecker) buildClient() (v1.API, error) {
-	cl := retryablehttp.NewClient()
-	cl.Logger = &ZerologAdapter{}
-	cl.RetryMax = 3
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.TLSClientConfig.GetClientCertificate = c.LoadTlsClientCerts
-	cl.HTTPClient.Transport = transport
+	if errs != nil {
+		return nil, errs
+	}
 
-	client, err := api.NewClient(api.Config{
-		Address: c.address,
-		Client:  cl.StandardClient(),
-	})
+	client, err := getPrometheusClient(address, checker.clientSettings)
 	if err != nil {
 		return nil, fmt.Errorf("could not build prometheus client: %w", err)
 	}
 
-	return v1.NewAPI(client), nil
+	checker.client = client
+	return checker, nil
 }
 
-func (c *PrometheusChecker) LoadTlsClientCerts(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-	if len(c.clientCertFile) == 0 || len(c.clientKeyFile) == 0 {
-		return nil, errors.New("no client certificates defined")
+func getPrometheusClient(address string, settings httpClientSettings) (v1.API, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	key := address + "\x00" + settings.key()
+	if client, ok := clients[key]; ok {
+		return client, nil
 	}
 
-	certificate, err := tls.LoadX509KeyPair(c.clientCertFile, c.clientKeyFile)
+	httpClient, err := buildRetryableHttpClient(settings)
 	if err != nil {
-		log.Error().Err(err).Msg("user-defined client certificates could not be loaded")
+		return nil, err
 	}
-	return &certificate, err
+
+	client, err := api.NewClient(api.Config{
+		Address: address,
+		Client:  httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clients[key] = v1.NewAPI(client)
+	return clients[key], nil
 }
 
 func (c *PrometheusChecker) Name() string {
@@ -115,6 +123,8 @@ func (c *PrometheusChecker) Name() string {
 }
 
 func (c *PrometheusChecker) IsHealthy(ctx context.Context) (bool, error) {
+	c.setReason("")
+
 	isHealthy := false
 	for name, query := range c.queries {
 		result, err := c.query(ctx, name, query)
@@ -131,8 +141,24 @@ func (c *PrometheusChecker) IsHealthy(ctx context.Context) (bool, error) {
 	return isHealthy, nil
 }
 
+func (c *PrometheusChecker) Reason() string {
+	c.reasonMutex.Lock()
+	defer c.reasonMutex.Unlock()
+	return c.reason
+}
+
+func (c *PrometheusChecker) setReason(reason string) {
+	c.reasonMutex.Lock()
+	defer c.reasonMutex.Unlock()
+	c.reason = reason
+}
+
 func (c *PrometheusChecker) query(ctx context.Context, name, query string) (bool, error) {
-	result, warnings, err := c.client.Query(ctx, query, time.Now(), v1.WithTimeout(5*time.Second))
+	// the timeout is also enforced locally, as the server-side timeout does not help against a stalled server
+	ctx, cancel := context.WithTimeout(ctx, c.queryTimeout)
+	defer cancel()
+
+	result, warnings, err := c.client.Query(ctx, query, time.Now(), v1.WithTimeout(c.queryTimeout))
 	if err != nil {
 		return false, err
 	}
@@ -141,8 +167,26 @@ func (c *PrometheusChecker) query(ctx context.Context, name, query string) (bool
 		log.Warn().Str("checker", "prometheus").Msgf("warning for query '%s': %v", name, warnings)
 	}
 
-	vec := result.(model.Vector)
-	return c.evaluateResponse(len(vec)), nil
+	expectation, ok := c.expectations[name]
+	if !ok {
+		vec, ok := result.(model.Vector)
+		if !ok {
+			return false, fmt.Errorf("expected vector result, got %s", result.Type())
+		}
+		return c.evaluateResponse(len(vec)), nil
+	}
+
+	isHealthy, offending, err := expectation.evaluate(result)
+	if err != nil {
+		return false, err
+	}
+
+	if !isHealthy {
+		log.Warn().Str("checker", "prometheus").Strs("series", offending).Msgf("Query '%s' violates expectation '%s'", name, expectation)
+		c.setReason(fmt.Sprintf("query '%s' violates '%s': %s", name, expectation, strings.Join(offending, ", ")))
+	}
+
+	return isHealthy, nil
 }
 
 func (c *PrometheusChecker) evaluateResponse(responseLength int) bool {
@@ -0,0 +1,112 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	ExpectationOpLess    = "<"
+	ExpectationOpGreater = ">"
+	ExpectationOpEqual   = "=="
+	ExpectationOpRange   = "range"
+
+	ExpectationModeAll = "all"
+	ExpectationModeAny = "any"
+)
+
+// PrometheusExpectation describes the condition a query's samples have to satisfy for the checker to be healthy. With
+// mode 'all' every series has to satisfy the condition, with mode 'any' a single series suffices.
+type PrometheusExpectation struct {
+	Op    string
+	Value float64
+	Min   float64
+	Max   float64
+	Mode  string
+}
+
+func (e PrometheusExpectation) Validate() error {
+	switch e.Op {
+	case ExpectationOpLess, ExpectationOpGreater, ExpectationOpEqual:
+	case ExpectationOpRange:
+		if e.Min > e.Max {
+			return fmt.Errorf("min %v must not be greater than max %v", e.Min, e.Max)
+		}
+	default:
+		return fmt.Errorf("unknown op %q", e.Op)
+	}
+
+	switch e.Mode {
+	case "", ExpectationModeAll, ExpectationModeAny:
+	default:
+		return fmt.Errorf("unknown mode %q", e.Mode)
+	}
+
+	return nil
+}
+
+func (e PrometheusExpectation) String() string {
+	if e.Op == ExpectationOpRange {
+		return fmt.Sprintf("in [%v, %v]", e.Min, e.Max)
+	}
+	return fmt.Sprintf("%s %v", e.Op, e.Value)
+}
+
+func (e PrometheusExpectation) isSatisfied(val float64) bool {
+	switch e.Op {
+	case ExpectationOpLess:
+		return val < e.Value
+	case ExpectationOpGreater:
+		return val > e.Value
+	case ExpectationOpEqual:
+		return val == e.Value
+	case ExpectationOpRange:
+		return val >= e.Min && val <= e.Max
+	}
+
+	return false
+}
+
+// evaluate checks the result of an instant query against the expectation and returns the series that violate it.
+// Queries that return no samples can not be evaluated and yield an error.
+func (e PrometheusExpectation) evaluate(result model.Value) (bool, []string, error) {
+	type sample struct {
+		labels string
+		value  float64
+	}
+
+	var samples []sample
+	switch res := result.(type) {
+	case *model.Scalar:
+		samples = append(samples, sample{labels: "scalar", value: float64(res.Value)})
+	case model.Vector:
+		for _, s := range res {
+			samples = append(samples, sample{labels: s.Metric.String(), value: float64(s.Value)})
+		}
+	default:
+		return false, nil, fmt.Errorf("unsupported result type %s", result.Type())
+	}
+
+	if len(samples) == 0 {
+		return false, nil, errors.New("query returned no samples")
+	}
+
+	var offending []string
+	for _, s := range samples {
+		if math.IsNaN(s.value) || !e.isSatisfied(s.value) {
+			offending = append(offending, fmt.Sprintf("%s=%v", s.labels, s.value))
+		}
+	}
+
+	if e.Mode == ExpectationModeAny {
+		if len(offending) < len(samples) {
+			return true, nil, nil
+		}
+		return false, offending, nil
+	}
+
+	return len(offending) == 0, offending, nil
+}
@@ -2,6 +2,8 @@ package checkers
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
 func ExceptsResponse(expectsResponse bool) PrometheusOpts {
@@ -13,8 +15,110 @@ func ExceptsResponse(expectsResponse bool) PrometheusOpts {
 
 func UseTls(certFile, keyFile string) PrometheusOpts {
 	return func(checker *PrometheusChecker) error {
-		checker.clientCertFile = certFile
-		checker.clientKeyFile = keyFile
+		if len(certFile) == 0 || len(keyFile) == 0 {
+			return errors.New("both client certificate and key need to be supplied")
+		}
+
+		checker.clientSettings.clientCertFile = certFile
+		checker.clientSettings.clientKeyFile = keyFile
+		return nil
+	}
+}
+
+func PrometheusCaFile(caFile string) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if len(caFile) == 0 {
+			return errors.New("empty CA file provided")
+		}
+
+		checker.clientSettings.caFile = caFile
+		return nil
+	}
+}
+
+// PrometheusBearerToken authenticates requests with the token stored in the given file. The file is read on each
+// request.
+func PrometheusBearerToken(tokenFile string) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if len(tokenFile) == 0 {
+			return errors.New("empty bearer token file provided")
+		}
+
+		checker.clientSettings.bearerTokenFile = tokenFile
+		return nil
+	}
+}
+
+// PrometheusBasicAuth authenticates requests using basic auth. The password is either given verbatim or read from
+// passwordFile on each request.
+func PrometheusBasicAuth(username, password, passwordFile string) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if len(username) == 0 {
+			return errors.New("empty username provided")
+		}
+
+		checker.clientSettings.username = username
+		checker.clientSettings.password = password
+		checker.clientSettings.passwordFile = passwordFile
+		return nil
+	}
+}
+
+// PrometheusBinding sends requests from a source address, an interface or a network namespace.
+func PrometheusBinding(binding NetworkBinding) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if err := binding.validate(); err != nil {
+			return err
+		}
+
+		checker.clientSettings.binding = binding
+		return nil
+	}
+}
+
+func PrometheusProxy(proxy string) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if len(proxy) == 0 {
+			return errors.New("empty proxy provided")
+		}
+
+		checker.clientSettings.proxy = proxy
+		return nil
+	}
+}
+
+// prometheusHttpClient sets the TLS, authentication, proxy and network binding settings of the client at once.
+func prometheusHttpClient(settings httpClientSettings) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		checker.clientSettings = settings
+		return nil
+	}
+}
+
+func PrometheusQueryTimeout(timeout time.Duration) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if timeout <= 0 {
+			return errors.New("query timeout must be positive")
+		}
+
+		checker.queryTimeout = timeout
+		return nil
+	}
+}
+
+// QueryExpectation compares the samples returned by the named query against the given expectation instead of only
+// checking whether the query returned any samples.
+func QueryExpectation(name string, expectation PrometheusExpectation) PrometheusOpts {
+	return func(checker *PrometheusChecker) error {
+		if _, ok := checker.queries[name]; !ok {
+			return fmt.Errorf("expectation for unknown query '%s'", name)
+		}
+
+		if err := expectation.Validate(); err != nil {
+			return fmt.Errorf("invalid expectation for query '%s': %w", name, err)
+		}
+
+		checker.expectations[name] = expectation
 		return nil
 	}
 }
@@ -43,24 +147,86 @@ func PrometheusCheckerFromMap(args map[string]any) (*PrometheusChecker, error) {
 	if !ok {
 		return nil, errors.New("'queries' is not of type map[string]string")
 	}
+	var opts []PrometheusOpts
 	queriesMap := map[string]string{}
 	for k := range queriesTmp {
-		if v, ok := queriesTmp[k].(string); ok {
+		switch v := queriesTmp[k].(type) {
+		case string:
 			queriesMap[k] = v
+		case map[string]any:
+			query, expectation, err := prometheusQueryFromMap(v)
+			if err != nil {
+				return nil, fmt.Errorf("could not build prometheus checker, invalid query '%s': %w", k, err)
+			}
+			queriesMap[k] = query
+			opts = append(opts, QueryExpectation(k, expectation))
 		}
 	}
 
-	var opts []PrometheusOpts
 	wantResponse, ok := args["wantResponse"].(bool)
-	if !ok {
+	if ok {
 		opts = append(opts, ExceptsResponse(wantResponse))
 	}
 
-	clientCert, okCert := args["tls_client_cert"].(string)
-	clientKey, okKey := args["tls_client_key"].(string)
-	if okCert && okKey {
-		opts = append(opts, UseTls(clientCert, clientKey))
+	settings, err := httpClientSettingsFromArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("could not build prometheus checker: %w", err)
+	}
+	opts = append(opts, prometheusHttpClient(settings))
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, PrometheusQueryTimeout(timeout))
 	}
 
 	return NewPrometheusChecker(name, address, queriesMap, opts...)
 }
+
+// prometheusQueryFromMap parses a query that is accompanied by an expectation, e.g.
+// {query: 'node_load15', op: '<', value: 8, mode: 'all'}.
+func prometheusQueryFromMap(args map[string]any) (string, PrometheusExpectation, error) {
+	expectation := PrometheusExpectation{}
+
+	query, ok := args["query"].(string)
+	if !ok || len(query) == 0 {
+		return "", expectation, errors.New("no 'query' supplied")
+	}
+
+	expectation.Op, ok = args["op"].(string)
+	if !ok {
+		return "", expectation, errors.New("no 'op' supplied")
+	}
+
+	if mode, ok := args["mode"].(string); ok {
+		expectation.Mode = mode
+	}
+
+	var err error
+	if expectation.Op == ExpectationOpRange {
+		var okMin, okMax bool
+		expectation.Min, okMin, err = floatFromArgs(args, "min")
+		if err != nil {
+			return "", expectation, err
+		}
+		expectation.Max, okMax, err = floatFromArgs(args, "max")
+		if err != nil {
+			return "", expectation, err
+		}
+		if !okMin || !okMax {
+			return "", expectation, errors.New("op 'range' requires 'min' and 'max'")
+		}
+	} else {
+		expectation.Value, ok, err = floatFromArgs(args, "value")
+		if err != nil {
+			return "", expectation, err
+		}
+		if !ok {
+			return "", expectation, errors.New("no 'value' supplied")
+		}
+	}
+
+	return query, expectation, nil
+}
@@ -1,7 +1,12 @@
 package checkers
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestPrometheusChecker_evaluateResponse(t *testing.T) {
@@ -47,3 +52,336 @@ func TestPrometheusChecker_evaluateResponse(t *testing.T) {
 		})
 	}
 }
+
+func newPrometheusServer(t *testing.T, results map[string]string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		result, ok := results[r.FormValue("query")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"unknown query"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":` + result + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestPrometheusChecker(t *testing.T, address string, queries map[string]string, opts ...PrometheusOpts) *PrometheusChecker {
+	t.Helper()
+
+	checker, err := NewPrometheusChecker("test", address, queries, opts...)
+	if err != nil {
+		t.Fatalf("NewPrometheusChecker() error = %v", err)
+	}
+	return checker
+}
+
+func TestPrometheusChecker_IsHealthy_Expectations(t *testing.T) {
+	results := map[string]string{
+		"node_load15": `{"resultType":"vector","result":[
+			{"metric":{"instance":"a"},"value":[1700000000,"0.5"]},
+			{"metric":{"instance":"b"},"value":[1700000000,"12"]}]}`,
+		"scalar(up)": `{"resultType":"scalar","result":[1700000000,"1"]}`,
+		"absent":     `{"resultType":"vector","result":[]}`,
+		"matrix":     `{"resultType":"matrix","result":[]}`,
+	}
+	srv := newPrometheusServer(t, results)
+
+	tests := []struct {
+		name        string
+		query       string
+		expectation *PrometheusExpectation
+		want        bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{
+			name:       "legacy presence semantics",
+			query:      "absent",
+			want:       true,
+			wantReason: "",
+		},
+		{
+			name:        "all series below threshold",
+			query:       "node_load15",
+			expectation: &PrometheusExpectation{Op: ExpectationOpLess, Value: 16},
+			want:        true,
+		},
+		{
+			name:        "single series above threshold",
+			query:       "node_load15",
+			expectation: &PrometheusExpectation{Op: ExpectationOpLess, Value: 8},
+			want:        false,
+			wantReason:  `query 'q' violates '< 8': {instance="b"}=12`,
+		},
+		{
+			name:        "any series below threshold",
+			query:       "node_load15",
+			expectation: &PrometheusExpectation{Op: ExpectationOpLess, Value: 8, Mode: ExpectationModeAny},
+			want:        true,
+		},
+		{
+			name:        "range",
+			query:       "node_load15",
+			expectation: &PrometheusExpectation{Op: ExpectationOpRange, Min: 1, Max: 20},
+			want:        false,
+			wantReason:  `query 'q' violates 'in [1, 20]': {instance="a"}=0.5`,
+		},
+		{
+			name:        "scalar equals",
+			query:       "scalar(up)",
+			expectation: &PrometheusExpectation{Op: ExpectationOpEqual, Value: 1},
+			want:        true,
+		},
+		{
+			name:        "scalar greater",
+			query:       "scalar(up)",
+			expectation: &PrometheusExpectation{Op: ExpectationOpGreater, Value: 1},
+			want:        false,
+			wantReason:  `query 'q' violates '> 1': scalar=1`,
+		},
+		{
+			name:        "no samples",
+			query:       "absent",
+			expectation: &PrometheusExpectation{Op: ExpectationOpLess, Value: 1},
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported result type",
+			query:       "matrix",
+			expectation: &PrometheusExpectation{Op: ExpectationOpLess, Value: 1},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []PrometheusOpts
+			if tt.expectation != nil {
+				opts = append(opts, QueryExpectation("q", *tt.expectation))
+			}
+			c := newTestPrometheusChecker(t, srv.URL, map[string]string{"q": tt.query}, opts...)
+
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_prometheusQueryFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    PrometheusExpectation
+		wantErr bool
+	}{
+		{
+			name: "threshold",
+			args: map[string]any{"query": "node_load15", "op": "<", "value": 8},
+			want: PrometheusExpectation{Op: ExpectationOpLess, Value: 8},
+		},
+		{
+			name: "range",
+			args: map[string]any{"query": "node_load15", "op": "range", "min": 0.5, "max": 8, "mode": "any"},
+			want: PrometheusExpectation{Op: ExpectationOpRange, Min: 0.5, Max: 8, Mode: ExpectationModeAny},
+		},
+		{
+			name:    "range without max",
+			args:    map[string]any{"query": "node_load15", "op": "range", "min": 0.5},
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			args:    map[string]any{"query": "node_load15", "op": ">"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := prometheusQueryFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("prometheusQueryFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("prometheusQueryFromMap() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrometheusChecker_Auth(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	writeFixture(t, tokenFile, "s3cr3t\n")
+	passwordFile := filepath.Join(dir, "password")
+	writeFixture(t, passwordFile, "hunter2\n")
+
+	tests := []struct {
+		name       string
+		opts       []PrometheusOpts
+		wantHeader string
+	}{
+		{
+			name:       "bearer token",
+			opts:       []PrometheusOpts{PrometheusBearerToken(tokenFile)},
+			wantHeader: "Bearer s3cr3t",
+		},
+		{
+			name:       "basic auth",
+			opts:       []PrometheusOpts{PrometheusBasicAuth("prometheus", "", passwordFile)},
+			wantHeader: "Basic cHJvbWV0aGV1czpodW50ZXIy",
+		},
+		{
+			name: "no auth",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+			}))
+			defer srv.Close()
+
+			c := newTestPrometheusChecker(t, srv.URL, map[string]string{"q": "up"}, tt.opts...)
+
+			if _, err := c.IsHealthy(context.Background()); err != nil {
+				t.Fatalf("IsHealthy() error = %v", err)
+			}
+			if gotHeader != tt.wantHeader {
+				t.Errorf("Authorization header = %q, want %q", gotHeader, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestPrometheusChecker_IsHealthy_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := newTestPrometheusChecker(t, srv.URL, map[string]string{"q": "up"}, PrometheusQueryTimeout(100*time.Millisecond))
+
+	start := time.Now()
+	if _, err := c.IsHealthy(context.Background()); err == nil {
+		t.Errorf("IsHealthy() expected error for hanging server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("IsHealthy() took %s, expected query timeout to be enforced", elapsed)
+	}
+}
+
+func TestNewPrometheusChecker_ClientCache(t *testing.T) {
+	address := "https://prometheus.example.com"
+	queries := map[string]string{"q": "up"}
+	dir := t.TempDir()
+
+	a, err := NewPrometheusChecker("a", address, queries, PrometheusBearerToken(filepath.Join(dir, "a")))
+	if err != nil {
+		t.Fatalf("NewPrometheusChecker() error = %v", err)
+	}
+	b, err := NewPrometheusChecker("b", address, queries, PrometheusBearerToken(filepath.Join(dir, "b")))
+	if err != nil {
+		t.Fatalf("NewPrometheusChecker() error = %v", err)
+	}
+	c, err := NewPrometheusChecker("c", address, queries, PrometheusBearerToken(filepath.Join(dir, "a")))
+	if err != nil {
+		t.Fatalf("NewPrometheusChecker() error = %v", err)
+	}
+
+	if a.client == b.client {
+		t.Errorf("checkers with different settings share a client")
+	}
+	if a.client != c.client {
+		t.Errorf("checkers with identical settings do not share a client")
+	}
+}
+
+func TestPrometheusCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             map[string]any
+		wantWantResponse bool
+		wantErr          bool
+	}{
+		{
+			name: "default",
+			args: map[string]any{"name": "n", "address": "http://localhost:9090", "queries": map[string]any{"q": "up"}},
+		},
+		{
+			name:             "wantResponse",
+			args:             map[string]any{"name": "n", "address": "http://localhost:9090", "queries": map[string]any{"q": "up"}, "wantResponse": true},
+			wantWantResponse: true,
+		},
+		{
+			name:    "client cert without key",
+			args:    map[string]any{"name": "n", "address": "http://localhost:9090", "queries": map[string]any{"q": "up"}, "tls_client_cert": "/tmp/cert.pem"},
+			wantErr: true,
+		},
+		{
+			name:    "password without username",
+			args:    map[string]any{"name": "n", "address": "http://localhost:9090", "queries": map[string]any{"q": "up"}, "password": "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "bearer token and basic auth",
+			args:    map[string]any{"name": "n", "address": "http://localhost:9090", "queries": map[string]any{"q": "up"}, "bearer_token_file": "/tmp/token", "username": "u"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrometheusCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PrometheusCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.wantResponse != tt.wantWantResponse {
+				t.Errorf("wantResponse = %v, want %v", got.wantResponse, tt.wantWantResponse)
+			}
+		})
+	}
+}
+
+func TestNewPrometheusChecker_HostTemplate(t *testing.T) {
+	t.Setenv("CONDITIONAL_REBOOT_ALERT", "RebootRequired")
+
+	c, err := NewPrometheusChecker("test", "http://localhost:9090", map[string]string{
+		"alert": `ALERTS{alertname="{{ .Env.CONDITIONAL_REBOOT_ALERT }}"}`,
+	})
+	if err != nil {
+		t.Fatalf("NewPrometheusChecker() error = %v", err)
+	}
+
+	if want := `ALERTS{alertname="RebootRequired"}`; c.queries["alert"] != want {
+		t.Errorf("query = %v, want %v", c.queries["alert"], want)
+	}
+
+	if _, err := NewPrometheusChecker("test", "http://localhost:9090", map[string]string{"alert": "{{ .Env.UNDEFINED_VARIABLE_XYZ }}"}); err == nil {
+		t.Errorf("NewPrometheusChecker() expected error for undefined env variable")
+	}
+}
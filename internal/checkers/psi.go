@@ -0,0 +1,261 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const PsiCheckerName = "psi"
+
+var (
+	psiResources = []string{"cpu", "memory", "io"}
+	psiKinds     = []string{"some", "full"}
+	psiWindows   = []string{"avg10", "avg60", "avg300"}
+)
+
+// PressureSource provides the raw contents of the kernel's pressure stall information and vm statistics.
+type PressureSource interface {
+	Pressure(resource string) ([]byte, error)
+	VmStat() ([]byte, error)
+}
+
+// ProcfsPressureSource reads pressure stall information from a procfs mounted at root.
+type ProcfsPressureSource struct {
+	root string
+}
+
+func (p *ProcfsPressureSource) Pressure(resource string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(p.root, "pressure", resource))
+}
+
+func (p *ProcfsPressureSource) VmStat() ([]byte, error) {
+	return os.ReadFile(filepath.Join(p.root, "vmstat"))
+}
+
+// PsiChecker reports an unhealthy state if pressure stall information exceeds configured thresholds, e.g. when a
+// system is thrashing, or if the OOM killer was invoked too often since the last check. Thresholds are keyed by
+// '<resource>.<some|full>.<avg10|avg60|avg300>', such as 'memory.full.avg60'.
+type PsiChecker struct {
+	source     PressureSource
+	thresholds map[string]float64
+	maxOomKill int
+
+	mutex       sync.Mutex
+	lastOomKill int64
+	hasOomKill  bool
+	reason      string
+}
+
+type PsiOpts func(checker *PsiChecker) error
+
+func NewPsiChecker(opts ...PsiOpts) (*PsiChecker, error) {
+	checker := &PsiChecker{
+		source:     &ProcfsPressureSource{root: defaultProcRoot},
+		thresholds: map[string]float64{},
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if len(checker.thresholds) == 0 && checker.maxOomKill == 0 {
+		errs = multierr.Append(errs, errors.New("neither thresholds nor oom kills configured"))
+	}
+
+	return checker, errs
+}
+
+func (c *PsiChecker) Name() string {
+	return PsiCheckerName
+}
+
+func (c *PsiChecker) IsHealthy(_ context.Context) (bool, error) {
+	var problems []string
+
+	pressure, err := c.readPressure()
+	if err != nil {
+		return false, err
+	}
+
+	keys := make([]string, 0, len(c.thresholds))
+	for key := range c.thresholds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val, ok := pressure[key]
+		if !ok {
+			return false, fmt.Errorf("no pressure stall information for %q available", key)
+		}
+		if val > c.thresholds[key] {
+			problems = append(problems, fmt.Sprintf("%s=%.2f exceeds %.2f", key, val, c.thresholds[key]))
+		}
+	}
+
+	if c.maxOomKill > 0 {
+		delta, err := c.oomKillDelta()
+		if err != nil {
+			return false, err
+		}
+		if delta >= int64(c.maxOomKill) {
+			problems = append(problems, fmt.Sprintf("%d oom kills since last check", delta))
+		}
+	}
+
+	c.mutex.Lock()
+	c.reason = strings.Join(problems, ", ")
+	c.mutex.Unlock()
+
+	if len(problems) > 0 {
+		log.Warn().Str("checker", "psi").Strs("problems", problems).Msg("System under pressure")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (c *PsiChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
+
+// readPressure reads the pressure files of all resources that thresholds are configured for.
+func (c *PsiChecker) readPressure() (map[string]float64, error) {
+	ret := map[string]float64{}
+	for _, resource := range psiResources {
+		needed := false
+		for key := range c.thresholds {
+			if strings.HasPrefix(key, resource+".") {
+				needed = true
+				break
+			}
+		}
+		if !needed {
+			continue
+		}
+
+		data, err := c.source.Pressure(resource)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s pressure: %w", resource, err)
+		}
+
+		values, err := parsePressure(data)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s pressure: %w", resource, err)
+		}
+		for key, val := range values {
+			ret[resource+"."+key] = val
+		}
+	}
+
+	return ret, nil
+}
+
+// oomKillDelta returns the number of oom kills since the previous check. The first check only records the counter.
+func (c *PsiChecker) oomKillDelta() (int64, error) {
+	data, err := c.source.VmStat()
+	if err != nil {
+		return 0, fmt.Errorf("could not read vmstat: %w", err)
+	}
+
+	oomKill, err := parseVmStatCounter(data, "oom_kill")
+	if err != nil {
+		return 0, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var delta int64
+	if c.hasOomKill && oomKill >= c.lastOomKill {
+		delta = oomKill - c.lastOomKill
+	}
+	c.lastOomKill = oomKill
+	c.hasOomKill = true
+	return delta, nil
+}
+
+// parsePressure parses the format of /proc/pressure/<resource>, e.g.
+// 'some avg10=0.00 avg60=0.00 avg300=0.00 total=0', and returns keys such as 'some.avg10'.
+func parsePressure(data []byte) (map[string]float64, error) {
+	ret := map[string]float64{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		kind := fields[0]
+		for _, field := range fields[1:] {
+			key, val, found := strings.Cut(field, "=")
+			if !found || !strings.HasPrefix(key, "avg") {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q: %w", field, err)
+			}
+			ret[kind+"."+key] = parsed
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("no values found")
+	}
+
+	return ret, nil
+}
+
+func parseVmStatCounter(data []byte, counter string) (int64, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == counter {
+			return strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+
+	return 0, fmt.Errorf("counter %q not found in vmstat", counter)
+}
+
+func isValidPsiKey(key string) bool {
+	parts := strings.Split(key, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	for idx, valid := range [][]string{psiResources, psiKinds, psiWindows} {
+		found := false
+		for _, v := range valid {
+			if parts[idx] == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/multierr"
+)
+
+func PsiProcRoot(root string) PsiOpts {
+	return func(checker *PsiChecker) error {
+		if len(root) == 0 {
+			return errors.New("empty proc root provided")
+		}
+
+		checker.source = &ProcfsPressureSource{root: root}
+		return nil
+	}
+}
+
+func PsiSource(source PressureSource) PsiOpts {
+	return func(checker *PsiChecker) error {
+		if source == nil {
+			return errors.New("nil pressure source provided")
+		}
+
+		checker.source = source
+		return nil
+	}
+}
+
+// PsiThresholds sets the thresholds in percent, keyed by '<cpu|memory|io>.<some|full>.<avg10|avg60|avg300>'.
+func PsiThresholds(thresholds map[string]float64) PsiOpts {
+	return func(checker *PsiChecker) error {
+		var errs error
+		for key, val := range thresholds {
+			if !isValidPsiKey(key) {
+				errs = multierr.Append(errs, fmt.Errorf("invalid threshold key %q", key))
+				continue
+			}
+			if val < 0 || val > 100 {
+				errs = multierr.Append(errs, fmt.Errorf("threshold %q must be within [0, 100]", key))
+				continue
+			}
+			checker.thresholds[key] = val
+		}
+
+		return errs
+	}
+}
+
+// PsiMaxOomKills sets the number of oom kills between two checks that marks the system as unhealthy.
+func PsiMaxOomKills(maxOomKills int) PsiOpts {
+	return func(checker *PsiChecker) error {
+		if maxOomKills < 1 {
+			return fmt.Errorf("oom kills must be at least 1, got %d", maxOomKills)
+		}
+
+		checker.maxOomKill = maxOomKills
+		return nil
+	}
+}
+
+func PsiCheckerFromMap(args map[string]any) (*PsiChecker, error) {
+	var opts []PsiOpts
+	if root, ok := args["proc_root"].(string); ok {
+		opts = append(opts, PsiProcRoot(root))
+	}
+
+	thresholds, ok, err := floatMapFromArgs(args, "thresholds")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, PsiThresholds(thresholds))
+	}
+
+	oomKills, ok, err := intFromArgs(args, "oom_kills")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, PsiMaxOomKills(oomKills))
+	}
+
+	return NewPsiChecker(opts...)
+}
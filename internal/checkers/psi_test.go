@@ -0,0 +1,141 @@
+package checkers
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	procPressureMemory = `some avg10=12.50 avg60=8.20 avg300=2.10 total=123456789
+full avg10=4.00 avg60=31.75 avg300=1.00 total=98765432
+`
+	procPressureIo = `some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+`
+)
+
+func Test_parsePressure(t *testing.T) {
+	got, err := parsePressure([]byte(procPressureMemory))
+	if err != nil {
+		t.Fatalf("parsePressure() error = %v", err)
+	}
+
+	want := map[string]float64{
+		"some.avg10":  12.5,
+		"some.avg60":  8.2,
+		"some.avg300": 2.1,
+		"full.avg10":  4,
+		"full.avg60":  31.75,
+		"full.avg300": 1,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePressure() = %v, want %v", got, want)
+	}
+
+	if _, err := parsePressure([]byte("")); err == nil {
+		t.Errorf("parsePressure() expected error for empty input")
+	}
+}
+
+func TestPsiChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds map[string]float64
+		want       bool
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "below thresholds",
+			thresholds: map[string]float64{"memory.full.avg60": 40, "io.some.avg10": 10},
+			want:       true,
+		},
+		{
+			name:       "memory above threshold",
+			thresholds: map[string]float64{"memory.full.avg60": 30, "memory.some.avg10": 10, "io.some.avg10": 10},
+			want:       false,
+			wantReason: "memory.full.avg60=31.75 exceeds 30.00, memory.some.avg10=12.50 exceeds 10.00",
+		},
+		{
+			name:       "pressure file missing",
+			thresholds: map[string]float64{"cpu.some.avg10": 10},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFixture(t, filepath.Join(root, "pressure", "memory"), procPressureMemory)
+			writeFixture(t, filepath.Join(root, "pressure", "io"), procPressureIo)
+
+			c, err := NewPsiChecker(PsiProcRoot(root), PsiThresholds(tt.thresholds))
+			if err != nil {
+				t.Fatalf("NewPsiChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+type pressureSourceDummy struct {
+	vmstat []string
+	idx    int
+}
+
+func (p *pressureSourceDummy) Pressure(_ string) ([]byte, error) {
+	return []byte(procPressureIo), nil
+}
+
+func (p *pressureSourceDummy) VmStat() ([]byte, error) {
+	data := p.vmstat[p.idx]
+	p.idx++
+	return []byte(data), nil
+}
+
+func TestPsiChecker_IsHealthy_OomKills(t *testing.T) {
+	source := &pressureSourceDummy{
+		vmstat: []string{
+			"pgfault 1000\noom_kill 5\n",
+			"pgfault 1200\noom_kill 5\n",
+			"pgfault 1400\noom_kill 8\n",
+			"pgfault 1600\noom_kill 9\n",
+		},
+	}
+
+	c, err := NewPsiChecker(PsiSource(source), PsiMaxOomKills(2))
+	if err != nil {
+		t.Fatalf("NewPsiChecker() error = %v", err)
+	}
+
+	want := []bool{true, true, false, true}
+	for idx := range want {
+		got, err := c.IsHealthy(context.Background())
+		if err != nil {
+			t.Fatalf("IsHealthy() error = %v", err)
+		}
+		if got != want[idx] {
+			t.Errorf("IsHealthy() #%d got = %v, want %v (%s)", idx, got, want[idx], c.Reason())
+		}
+	}
+}
+
+func TestPsiThresholds_Invalid(t *testing.T) {
+	for _, key := range []string{"memory.avg10", "disk.some.avg10", "cpu.all.avg10", "io.full.avg5"} {
+		if _, err := NewPsiChecker(PsiThresholds(map[string]float64{key: 10})); err == nil {
+			t.Errorf("NewPsiChecker() expected error for key %q", key)
+		}
+	}
+}
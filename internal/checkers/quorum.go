@@ -0,0 +1,123 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	QuorumCheckerName    = "quorum"
+	defaultQuorumTimeout = 30 * time.Second
+)
+
+// CheckerFactory builds a checker by its name and args. It is used to build nested checkers without importing the
+// code that knows about all checkers.
+type CheckerFactory func(name string, args map[string]any) (Checker, error)
+
+// QuorumChecker probes all targets in parallel and reports a healthy state if at least minHealthy of them are
+// healthy. Targets that return an error are considered unhealthy.
+type QuorumChecker struct {
+	targets    []Checker
+	minHealthy int
+	timeout    time.Duration
+
+	mutex  sync.Mutex
+	reason string
+}
+
+type QuorumOpts func(checker *QuorumChecker) error
+
+func NewQuorumChecker(targets []Checker, opts ...QuorumOpts) (*QuorumChecker, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("no 'targets' provided")
+	}
+
+	checker := &QuorumChecker{
+		targets:    targets,
+		minHealthy: 1,
+		timeout:    defaultQuorumTimeout,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if checker.minHealthy > len(targets) {
+		errs = multierr.Append(errs, fmt.Errorf("min healthy %d exceeds number of targets %d", checker.minHealthy, len(targets)))
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return checker, nil
+}
+
+func (c *QuorumChecker) Name() string {
+	return fmt.Sprintf("%s (%d/%d)", QuorumCheckerName, c.minHealthy, len(c.targets))
+}
+
+func (c *QuorumChecker) IsHealthy(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	healthy := make([]bool, len(c.targets))
+	var wg sync.WaitGroup
+	for idx, target := range c.targets {
+		wg.Add(1)
+		go func(idx int, target Checker) {
+			defer wg.Done()
+			isHealthy, err := target.IsHealthy(ctx)
+			if err != nil {
+				log.Warn().Str("checker", "quorum").Err(err).Msgf("Target '%s' returned error", target.Name())
+				return
+			}
+			healthy[idx] = isHealthy
+		}(idx, target)
+	}
+	wg.Wait()
+
+	var healthyCount int
+	var failed []string
+	for idx, isHealthy := range healthy {
+		if isHealthy {
+			healthyCount++
+		} else {
+			failed = append(failed, c.targets[idx].Name())
+		}
+	}
+
+	if healthyCount >= c.minHealthy {
+		c.setReason("")
+		if len(failed) > 0 {
+			log.Debug().Str("checker", "quorum").Strs("failed", failed).Msgf("Quorum reached with %d/%d healthy targets", healthyCount, len(c.targets))
+		}
+		return true, nil
+	}
+
+	c.setReason(fmt.Sprintf("%d/%d targets healthy, %d required, failed: %s", healthyCount, len(c.targets), c.minHealthy, strings.Join(failed, ", ")))
+	log.Warn().Str("checker", "quorum").Strs("failed", failed).Msgf("Quorum not reached, %d/%d targets healthy", healthyCount, len(c.targets))
+	return false, nil
+}
+
+func (c *QuorumChecker) setReason(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reason = reason
+}
+
+func (c *QuorumChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
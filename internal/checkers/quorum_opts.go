@@ -0,0 +1,105 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// quorumTargetCheckers contains the connectivity probes that can be targets of a quorum. Checkers that keep state
+// between checks or run in the background do not work with the per-check timeout of the quorum.
+var quorumTargetCheckers = map[string]bool{
+	TcpName:         true,
+	IcmpCheckerName: true,
+	DnsCheckerName:  true,
+	HttpCheckerName: true,
+}
+
+func quorumTargetCheckerNames() []string {
+	names := make([]string, 0, len(quorumTargetCheckers))
+	for name := range quorumTargetCheckers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func QuorumMinHealthy(minHealthy int) QuorumOpts {
+	return func(checker *QuorumChecker) error {
+		if minHealthy < 1 {
+			return fmt.Errorf("min healthy must be at least 1, got %d", minHealthy)
+		}
+
+		checker.minHealthy = minHealthy
+		return nil
+	}
+}
+
+// QuorumTimeout sets the time all targets have to finish their checks.
+func QuorumTimeout(timeout time.Duration) QuorumOpts {
+	return func(checker *QuorumChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+// QuorumCheckerFromMap builds the targets, given as list of 'checker_name' and 'checker_args', using the factory.
+func QuorumCheckerFromMap(args map[string]any, factory CheckerFactory) (*QuorumChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build quorum checker, empty args supplied")
+	}
+
+	targetsRaw, ok := args["targets"].([]any)
+	if !ok {
+		return nil, errors.New("could not build quorum checker, no 'targets' supplied")
+	}
+
+	targets := make([]Checker, 0, len(targetsRaw))
+	for idx, targetRaw := range targetsRaw {
+		target, ok := targetRaw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("could not build quorum checker, target %d is not a map", idx)
+		}
+
+		name, ok := target["checker_name"].(string)
+		if !ok {
+			return nil, fmt.Errorf("could not build quorum checker, target %d has no 'checker_name'", idx)
+		}
+
+		if !quorumTargetCheckers[name] {
+			return nil, fmt.Errorf("could not build quorum checker, target %d (%s) is not supported, only connectivity probes (%s) can be targets", idx, name, strings.Join(quorumTargetCheckerNames(), ", "))
+		}
+
+		targetArgs, _ := target["checker_args"].(map[string]any)
+		checker, err := factory(name, targetArgs)
+		if err != nil {
+			return nil, fmt.Errorf("could not build quorum checker, target %d (%s): %w", idx, name, err)
+		}
+		targets = append(targets, checker)
+	}
+
+	var opts []QuorumOpts
+	minHealthy, ok, err := intFromArgs(args, "min_healthy")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, QuorumMinHealthy(minHealthy))
+	}
+
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, QuorumTimeout(timeout))
+	}
+
+	return NewQuorumChecker(targets, opts...)
+}
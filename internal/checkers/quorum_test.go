@@ -0,0 +1,178 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type checkerDummy struct {
+	name    string
+	healthy bool
+	err     error
+	delay   time.Duration
+}
+
+func (c *checkerDummy) Name() string {
+	return c.name
+}
+
+func (c *checkerDummy) IsHealthy(ctx context.Context) (bool, error) {
+	select {
+	case <-time.After(c.delay):
+		return c.healthy, c.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func TestQuorumChecker_IsHealthy(t *testing.T) {
+	up := func(name string) Checker { return &checkerDummy{name: name, healthy: true} }
+	down := func(name string) Checker { return &checkerDummy{name: name} }
+
+	tests := []struct {
+		name       string
+		targets    []Checker
+		opts       []QuorumOpts
+		want       bool
+		wantReason string
+	}{
+		{
+			name:    "any target healthy by default",
+			targets: []Checker{down("a"), up("b"), down("c")},
+			want:    true,
+		},
+		{
+			name:    "quorum reached",
+			targets: []Checker{up("a"), up("b"), down("c")},
+			opts:    []QuorumOpts{QuorumMinHealthy(2)},
+			want:    true,
+		},
+		{
+			name:       "quorum not reached",
+			targets:    []Checker{up("a"), down("b"), down("c")},
+			opts:       []QuorumOpts{QuorumMinHealthy(2)},
+			want:       false,
+			wantReason: "1/3 targets healthy, 2 required, failed: b, c",
+		},
+		{
+			name:       "errors count as unhealthy",
+			targets:    []Checker{up("a"), &checkerDummy{name: "b", healthy: true, err: errors.New("oops")}},
+			opts:       []QuorumOpts{QuorumMinHealthy(2)},
+			want:       false,
+			wantReason: "1/2 targets healthy, 2 required, failed: b",
+		},
+		{
+			name:       "slow targets time out",
+			targets:    []Checker{up("a"), &checkerDummy{name: "b", healthy: true, delay: time.Second}},
+			opts:       []QuorumOpts{QuorumMinHealthy(2), QuorumTimeout(50 * time.Millisecond)},
+			want:       false,
+			wantReason: "1/2 targets healthy, 2 required, failed: b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewQuorumChecker(tt.targets, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewQuorumChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestQuorumCheckerFromMap(t *testing.T) {
+	factory := func(name string, args map[string]any) (Checker, error) {
+		if name != TcpName {
+			return nil, fmt.Errorf("unknown checker: %s", name)
+		}
+		return &checkerDummy{name: fmt.Sprintf("%v", args["host"]), healthy: true}, nil
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantTargets int
+		wantErr     bool
+	}{
+		{
+			name: "valid",
+			args: map[string]any{
+				"min_healthy": 2,
+				"timeout":     "10s",
+				"targets": []any{
+					map[string]any{"checker_name": TcpName, "checker_args": map[string]any{"host": "a"}},
+					map[string]any{"checker_name": TcpName, "checker_args": map[string]any{"host": "b"}},
+				},
+			},
+			wantTargets: 2,
+		},
+		{
+			name: "min healthy exceeds targets",
+			args: map[string]any{
+				"min_healthy": 3,
+				"targets": []any{
+					map[string]any{"checker_name": TcpName, "checker_args": map[string]any{"host": "a"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown target",
+			args: map[string]any{
+				"targets": []any{
+					map[string]any{"checker_name": "unknown"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "stateful target",
+			args: map[string]any{
+				"targets": []any{
+					map[string]any{"checker_name": KmsgCheckerName},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nested quorum",
+			args: map[string]any{
+				"targets": []any{
+					map[string]any{"checker_name": QuorumCheckerName},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no targets",
+			args:    map[string]any{"min_healthy": 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QuorumCheckerFromMap(tt.args, factory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("QuorumCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got.targets) != tt.wantTargets {
+				t.Errorf("QuorumCheckerFromMap() targets = %d, want %d", len(got.targets), tt.wantTargets)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	RebootRequiredCheckerName = "reboot_required"
+	defaultRebootRequiredFile = "/var/run/reboot-required"
+)
+
+// RebootRequiredChecker checks for the sentinel file that is created by Debian and Ubuntu packages that need a reboot
+// to be applied. The packages that requested the reboot are read from the accompanying '.pkgs' file.
+type RebootRequiredChecker struct {
+	file     string
+	pkgsFile string
+
+	mutex sync.Mutex
+	// packages is nil as long as no reboot is required
+	packages []string
+}
+
+func NewRebootRequiredChecker(file, pkgsFile string) (*RebootRequiredChecker, error) {
+	if len(file) == 0 {
+		return nil, errors.New("empty 'file' provided")
+	}
+
+	if len(pkgsFile) == 0 {
+		pkgsFile = file + ".pkgs"
+	}
+
+	return &RebootRequiredChecker{
+		file:     file,
+		pkgsFile: pkgsFile,
+	}, nil
+}
+
+func RebootRequiredCheckerFromMap(args map[string]any) (*RebootRequiredChecker, error) {
+	file := defaultRebootRequiredFile
+	if val, ok := args["file"].(string); ok {
+		file = val
+	}
+
+	pkgsFile, _ := args["pkgs_file"].(string)
+	return NewRebootRequiredChecker(file, pkgsFile)
+}
+
+func (c *RebootRequiredChecker) Name() string {
+	return fmt.Sprintf("%s://%s", RebootRequiredCheckerName, c.file)
+}
+
+func (c *RebootRequiredChecker) IsHealthy(_ context.Context) (bool, error) {
+	_, err := os.Stat(c.file)
+	if errors.Is(err, os.ErrNotExist) {
+		c.mutex.Lock()
+		c.packages = nil
+		c.mutex.Unlock()
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	packages, err := readRebootRequiredPkgs(c.pkgsFile)
+	if err != nil {
+		log.Warn().Str("checker", "reboot_required").Err(err).Msgf("could not read packages from '%s'", c.pkgsFile)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if strings.Join(packages, ",") != strings.Join(c.packages, ",") || c.packages == nil {
+		log.Info().Str("checker", "reboot_required").Strs("packages", packages).Msg("Reboot required")
+	}
+	c.packages = packages
+
+	return false, nil
+}
+
+func (c *RebootRequiredChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.packages == nil {
+		return ""
+	}
+	if len(c.packages) == 0 {
+		return "reboot required"
+	}
+	return fmt.Sprintf("reboot required by packages %s", strings.Join(c.packages, ", "))
+}
+
+func readRebootRequiredPkgs(file string) ([]string, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return []string{}, err
+	}
+
+	return parseRebootRequiredPkgs(data), nil
+}
+
+// parseRebootRequiredPkgs returns the unique packages, one per line, in the order they appear.
+func parseRebootRequiredPkgs(data []byte) []string {
+	packages := []string{}
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		pkg := strings.TrimSpace(scanner.Text())
+		if len(pkg) == 0 || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		packages = append(packages, pkg)
+	}
+
+	return packages
+}
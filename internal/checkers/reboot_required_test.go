@@ -0,0 +1,120 @@
+package checkers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRebootRequiredChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		sentinel   bool
+		pkgs       string
+		want       bool
+		wantReason string
+	}{
+		{
+			name:     "no reboot required",
+			sentinel: false,
+			want:     true,
+		},
+		{
+			name:       "reboot required without packages",
+			sentinel:   true,
+			want:       false,
+			wantReason: "reboot required",
+		},
+		{
+			name:       "reboot required with packages",
+			sentinel:   true,
+			pkgs:       "linux-image-6.1.0-13-amd64\nlibc6\nlinux-image-6.1.0-13-amd64\n",
+			want:       false,
+			wantReason: "reboot required by packages linux-image-6.1.0-13-amd64, libc6",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "reboot-required")
+			if tt.sentinel {
+				if err := os.WriteFile(file, []byte("*** System restart required ***\n"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(tt.pkgs) > 0 {
+				if err := os.WriteFile(file+".pkgs", []byte(tt.pkgs), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			c, err := NewRebootRequiredChecker(file, "")
+			if err != nil {
+				t.Fatalf("NewRebootRequiredChecker() error = %v", err)
+			}
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRebootRequiredChecker_Reason_Cleared(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "reboot-required")
+	if err := os.WriteFile(file, []byte("*** System restart required ***\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewRebootRequiredChecker(file, "")
+	if err != nil {
+		t.Fatalf("NewRebootRequiredChecker() error = %v", err)
+	}
+	if got, _ := c.IsHealthy(context.Background()); got {
+		t.Fatalf("IsHealthy() got = %v, want false", got)
+	}
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := c.IsHealthy(context.Background()); !got {
+		t.Fatalf("IsHealthy() got = %v, want true", got)
+	}
+	if got := c.Reason(); got != "" {
+		t.Errorf("Reason() got = %v, want empty reason", got)
+	}
+}
+
+func Test_parseRebootRequiredPkgs(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: []string{},
+		},
+		{
+			name: "duplicates and blank lines",
+			data: "libssl3\n\nlibc6\nlibssl3\n",
+			want: []string{"libssl3", "libc6"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRebootRequiredPkgs([]byte(tt.data)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRebootRequiredPkgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	SystemdCheckerName       = "systemd"
+	systemdLoadStateNotFound = "not-found"
+)
+
+// SystemdChecker reports an unhealthy state if any of the given units is in the 'failed' state or if the number of
+// failed units exceeds a threshold. Units are queried via D-Bus, falling back to systemctl if D-Bus is not available.
+type SystemdChecker struct {
+	units     []string
+	maxFailed int
+	source    SystemdUnits
+
+	mutex    sync.Mutex
+	problems []string
+}
+
+type SystemdOpts func(checker *SystemdChecker) error
+
+func NewSystemdChecker(opts ...SystemdOpts) (*SystemdChecker, error) {
+	checker := &SystemdChecker{
+		maxFailed: -1,
+		source: &systemdUnitsFallback{
+			primary:  &SystemdDbus{},
+			fallback: &SystemctlCmd{},
+		},
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if len(checker.units) == 0 && checker.maxFailed < 0 {
+		errs = multierr.Append(errs, errors.New("neither units nor max failed units provided"))
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return checker, nil
+}
+
+func (c *SystemdChecker) Name() string {
+	if len(c.units) == 0 {
+		return fmt.Sprintf("%s://*", SystemdCheckerName)
+	}
+	return fmt.Sprintf("%s://%s", SystemdCheckerName, strings.Join(c.units, ","))
+}
+
+func (c *SystemdChecker) IsHealthy(ctx context.Context) (bool, error) {
+	var problems []string
+
+	if len(c.units) > 0 {
+		states, err := c.source.States(ctx, c.units)
+		if err != nil {
+			return false, err
+		}
+
+		unitProblems, err := c.checkUnits(states)
+		if err != nil {
+			return false, err
+		}
+		problems = append(problems, unitProblems...)
+	}
+
+	if c.maxFailed >= 0 {
+		failed, err := c.source.Failed(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		if len(failed) > c.maxFailed {
+			names := make([]string, 0, len(failed))
+			for _, unit := range failed {
+				names = append(names, unit.Name)
+			}
+			problems = append(problems, fmt.Sprintf("%d failed units exceed %d: %s", len(failed), c.maxFailed, strings.Join(names, ", ")))
+		}
+	}
+
+	c.mutex.Lock()
+	c.problems = problems
+	c.mutex.Unlock()
+
+	if len(problems) > 0 {
+		log.Warn().Str("checker", "systemd").Strs("problems", problems).Msgf("Checker '%s' detected problems", c.Name())
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// checkUnits returns the configured units that have failed. Units that are not known to systemd are reported as
+// error, as they are most likely caused by a typo in the config.
+func (c *SystemdChecker) checkUnits(states []SystemdUnitState) ([]string, error) {
+	byName := make(map[string]SystemdUnitState, len(states))
+	for _, state := range states {
+		byName[state.Name] = state
+	}
+
+	var problems []string
+	for _, unit := range c.units {
+		state, found := byName[unit]
+		if !found || state.LoadState == systemdLoadStateNotFound {
+			return nil, fmt.Errorf("unit %s not found", unit)
+		}
+
+		if state.ActiveState == systemdActiveStateFailed {
+			problems = append(problems, state.String())
+		}
+	}
+
+	return problems, nil
+}
+
+func (c *SystemdChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return strings.Join(c.problems, ", ")
+}
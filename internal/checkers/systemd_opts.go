@@ -0,0 +1,104 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	SystemdBackendAuto      = "auto"
+	SystemdBackendDbus      = "dbus"
+	SystemdBackendSystemctl = "systemctl"
+)
+
+// SystemdUnitNames sets the units that must not be failed. Names without a type suffix are treated as services, as
+// systemctl does.
+func SystemdUnitNames(units []string) SystemdOpts {
+	return func(checker *SystemdChecker) error {
+		if len(units) == 0 {
+			return errors.New("no units provided")
+		}
+
+		checker.units = make([]string, 0, len(units))
+		for _, unit := range units {
+			if len(unit) == 0 {
+				return errors.New("empty unit name provided")
+			}
+			if !strings.Contains(unit, ".") {
+				unit += ".service"
+			}
+			checker.units = append(checker.units, unit)
+		}
+		return nil
+	}
+}
+
+// SystemdMaxFailedUnits marks the check as failed if more than maxFailed units are in the 'failed' state.
+func SystemdMaxFailedUnits(maxFailed int) SystemdOpts {
+	return func(checker *SystemdChecker) error {
+		if maxFailed < 0 {
+			return errors.New("max failed units must not be negative")
+		}
+
+		checker.maxFailed = maxFailed
+		return nil
+	}
+}
+
+// SystemdBackend selects how systemd is queried. By default, D-Bus is used and systemctl is only used as fallback.
+func SystemdBackend(backend string) SystemdOpts {
+	return func(checker *SystemdChecker) error {
+		switch backend {
+		case SystemdBackendAuto:
+			checker.source = &systemdUnitsFallback{primary: &SystemdDbus{}, fallback: &SystemctlCmd{}}
+		case SystemdBackendDbus:
+			checker.source = &SystemdDbus{}
+		case SystemdBackendSystemctl:
+			checker.source = &SystemctlCmd{}
+		default:
+			return fmt.Errorf("unknown backend %q", backend)
+		}
+		return nil
+	}
+}
+
+func SystemdSource(source SystemdUnits) SystemdOpts {
+	return func(checker *SystemdChecker) error {
+		if source == nil {
+			return errors.New("nil source provided")
+		}
+
+		checker.source = source
+		return nil
+	}
+}
+
+func SystemdCheckerFromMap(args map[string]any) (*SystemdChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("could not build systemd checker, empty args supplied")
+	}
+
+	var opts []SystemdOpts
+	units, ok, err := stringSliceFromArgs(args, "units")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, SystemdUnitNames(units))
+	}
+
+	maxFailed, ok, err := intFromArgs(args, "max_failed")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, SystemdMaxFailedUnits(maxFailed))
+	}
+
+	if backend, ok := args["backend"].(string); ok {
+		opts = append(opts, SystemdBackend(backend))
+	}
+
+	return NewSystemdChecker(opts...)
+}
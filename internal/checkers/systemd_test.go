@@ -0,0 +1,173 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const (
+	systemctlShow = `Id=systemd-networkd.service
+LoadState=loaded
+ActiveState=failed
+SubState=failed
+
+Id=containerd.service
+LoadState=loaded
+ActiveState=active
+SubState=running
+
+Id=typo.service
+LoadState=not-found
+ActiveState=inactive
+SubState=dead
+`
+	systemctlListFailed = `systemd-networkd.service loaded failed failed Network Configuration
+● logrotate.service      loaded failed failed Rotate log files
+`
+)
+
+func systemctlDummy(_ context.Context, args ...string) ([]byte, error) {
+	switch args[0] {
+	case "show":
+		return []byte(systemctlShow), nil
+	case "list-units":
+		return []byte(systemctlListFailed), nil
+	}
+	return nil, errors.New("unknown command")
+}
+
+func Test_parseSystemctlShow(t *testing.T) {
+	want := []SystemdUnitState{
+		{Name: "systemd-networkd.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed"},
+		{Name: "containerd.service", LoadState: "loaded", ActiveState: "active", SubState: "running"},
+		{Name: "typo.service", LoadState: "not-found", ActiveState: "inactive", SubState: "dead"},
+	}
+	if got := parseSystemctlShow([]byte(systemctlShow)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSystemctlShow() = %v, want %v", got, want)
+	}
+}
+
+func Test_parseSystemctlListUnits(t *testing.T) {
+	want := []SystemdUnitState{
+		{Name: "systemd-networkd.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed"},
+		{Name: "logrotate.service", LoadState: "loaded", ActiveState: "failed", SubState: "failed"},
+	}
+	if got := parseSystemctlListUnits([]byte(systemctlListFailed)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSystemctlListUnits() = %v, want %v", got, want)
+	}
+}
+
+type systemdUnitsError struct{}
+
+func (s *systemdUnitsError) States(_ context.Context, _ []string) ([]SystemdUnitState, error) {
+	return nil, errors.New("no dbus")
+}
+
+func (s *systemdUnitsError) Failed(_ context.Context) ([]SystemdUnitState, error) {
+	return nil, errors.New("no dbus")
+}
+
+func TestSystemdChecker_IsHealthy(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []SystemdOpts
+		want       bool
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name: "unit running",
+			opts: []SystemdOpts{SystemdUnitNames([]string{"containerd"})},
+			want: true,
+		},
+		{
+			name:       "unit failed",
+			opts:       []SystemdOpts{SystemdUnitNames([]string{"containerd", "systemd-networkd.service"})},
+			want:       false,
+			wantReason: "systemd-networkd.service (failed/failed)",
+		},
+		{
+			name:    "unit not found",
+			opts:    []SystemdOpts{SystemdUnitNames([]string{"typo"})},
+			wantErr: true,
+		},
+		{
+			name: "failed units within threshold",
+			opts: []SystemdOpts{SystemdMaxFailedUnits(2)},
+			want: true,
+		},
+		{
+			name:       "failed units exceed threshold",
+			opts:       []SystemdOpts{SystemdMaxFailedUnits(1)},
+			want:       false,
+			wantReason: "2 failed units exceed 1: systemd-networkd.service, logrotate.service",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]SystemdOpts{SystemdSource(&systemdUnitsFallback{
+				primary:  &systemdUnitsError{},
+				fallback: &SystemctlCmd{run: systemctlDummy},
+			})}, tt.opts...)
+			c, err := NewSystemdChecker(opts...)
+			if err != nil {
+				t.Fatalf("NewSystemdChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+			if c.Reason() != tt.wantReason {
+				t.Errorf("Reason() got = %v, want %v", c.Reason(), tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSystemdCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantUnits []string
+		wantErr   bool
+	}{
+		{
+			name:      "units",
+			args:      map[string]any{"units": []any{"systemd-networkd", "docker.socket"}, "backend": "systemctl"},
+			wantUnits: []string{"systemd-networkd.service", "docker.socket"},
+		},
+		{
+			name: "max failed",
+			args: map[string]any{"max_failed": 0},
+		},
+		{
+			name:    "neither units nor max failed",
+			args:    map[string]any{"backend": "dbus"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown backend",
+			args:    map[string]any{"max_failed": 0, "backend": "upstart"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SystemdCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SystemdCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.units, tt.wantUnits) {
+				t.Errorf("SystemdCheckerFromMap() units = %v, want %v", got.units, tt.wantUnits)
+			}
+		})
+	}
+}
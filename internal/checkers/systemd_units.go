@@ -0,0 +1,225 @@
+package checkers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const systemdActiveStateFailed = "failed"
+
+// SystemdUnitState holds the columns of a unit as shown by 'systemctl list-units'.
+type SystemdUnitState struct {
+	Name        string
+	LoadState   string
+	ActiveState string
+	SubState    string
+}
+
+func (s SystemdUnitState) String() string {
+	return fmt.Sprintf("%s (%s/%s)", s.Name, s.ActiveState, s.SubState)
+}
+
+// SystemdUnits returns the states of systemd units.
+type SystemdUnits interface {
+	// States returns the states of the given units, including units that are not loaded.
+	States(ctx context.Context, units []string) ([]SystemdUnitState, error)
+	// Failed returns all units in the 'failed' state.
+	Failed(ctx context.Context) ([]SystemdUnitState, error)
+}
+
+// SystemdDbus queries systemd via D-Bus. A new connection is established for each query, so a restarted dbus daemon
+// does not leave a stale connection behind.
+type SystemdDbus struct{}
+
+func (s *SystemdDbus) States(ctx context.Context, units []string) ([]SystemdUnitState, error) {
+	conn, err := dbus.NewWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to systemd: %w", err)
+	}
+	defer conn.Close()
+
+	statuses, err := conn.ListUnitsByNamesContext(ctx, units)
+	if err != nil {
+		return nil, fmt.Errorf("could not list units: %w", err)
+	}
+
+	return convertUnitStatuses(statuses), nil
+}
+
+func (s *SystemdDbus) Failed(ctx context.Context) ([]SystemdUnitState, error) {
+	conn, err := dbus.NewWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to systemd: %w", err)
+	}
+	defer conn.Close()
+
+	statuses, err := conn.ListUnitsFilteredContext(ctx, []string{systemdActiveStateFailed})
+	if err != nil {
+		return nil, fmt.Errorf("could not list failed units: %w", err)
+	}
+
+	return convertUnitStatuses(statuses), nil
+}
+
+func convertUnitStatuses(statuses []dbus.UnitStatus) []SystemdUnitState {
+	states := make([]SystemdUnitState, 0, len(statuses))
+	for _, status := range statuses {
+		states = append(states, SystemdUnitState{
+			Name:        status.Name,
+			LoadState:   status.LoadState,
+			ActiveState: status.ActiveState,
+			SubState:    status.SubState,
+		})
+	}
+
+	return states
+}
+
+// SystemctlCmd parses the output of 'systemctl show' and 'systemctl list-units'. It is used on systems where the
+// D-Bus API is not reachable, e.g. inside containers.
+type SystemctlCmd struct {
+	run func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+func (s *SystemctlCmd) States(ctx context.Context, units []string) ([]SystemdUnitState, error) {
+	args := append([]string{"show", "--property=Id,LoadState,ActiveState,SubState", "--"}, units...)
+	out, err := s.systemctl(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSystemctlShow(out), nil
+}
+
+func (s *SystemctlCmd) Failed(ctx context.Context) ([]SystemdUnitState, error) {
+	out, err := s.systemctl(ctx, "list-units", "--all", "--state=failed", "--plain", "--no-legend", "--no-pager")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSystemctlListUnits(out), nil
+}
+
+func (s *SystemctlCmd) systemctl(ctx context.Context, args ...string) ([]byte, error) {
+	if s.run != nil {
+		return s.run(ctx, args...)
+	}
+
+	out, err := exec.CommandContext(ctx, "systemctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not run systemctl: %w", err)
+	}
+
+	return out, nil
+}
+
+// parseSystemctlShow parses the properties of units, which are separated by empty lines.
+func parseSystemctlShow(out []byte) []SystemdUnitState {
+	var states []SystemdUnitState
+	var current SystemdUnitState
+
+	flush := func() {
+		if len(current.Name) > 0 {
+			states = append(states, current)
+		}
+		current = SystemdUnitState{}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			flush()
+			continue
+		}
+
+		key, val, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "Id":
+			current.Name = val
+		case "LoadState":
+			current.LoadState = val
+		case "ActiveState":
+			current.ActiveState = val
+		case "SubState":
+			current.SubState = val
+		}
+	}
+	flush()
+
+	return states
+}
+
+// parseSystemctlListUnits parses the columns 'UNIT LOAD ACTIVE SUB DESCRIPTION' of 'systemctl list-units --plain'.
+func parseSystemctlListUnits(out []byte) []SystemdUnitState {
+	var states []SystemdUnitState
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// older versions of systemd print a bullet in front of failed units despite '--plain'
+		if len(fields) > 0 && fields[0] == "●" {
+			fields = fields[1:]
+		}
+		if len(fields) < 4 {
+			continue
+		}
+
+		states = append(states, SystemdUnitState{
+			Name:        fields[0],
+			LoadState:   fields[1],
+			ActiveState: fields[2],
+			SubState:    fields[3],
+		})
+	}
+
+	return states
+}
+
+// systemdUnitsFallback queries the fallback if the primary source fails.
+type systemdUnitsFallback struct {
+	primary  SystemdUnits
+	fallback SystemdUnits
+}
+
+func (s *systemdUnitsFallback) States(ctx context.Context, units []string) ([]SystemdUnitState, error) {
+	states, err := s.primary.States(ctx, units)
+	if err == nil {
+		return states, nil
+	}
+
+	log.Warn().Str("checker", "systemd").Err(err).Msg("Querying systemd via D-Bus failed, falling back to systemctl")
+	states, fallbackErr := s.fallback.States(ctx, units)
+	if fallbackErr != nil {
+		return nil, multierr.Append(err, fallbackErr)
+	}
+
+	return states, nil
+}
+
+func (s *systemdUnitsFallback) Failed(ctx context.Context) ([]SystemdUnitState, error) {
+	states, err := s.primary.Failed(ctx)
+	if err == nil {
+		return states, nil
+	}
+
+	log.Warn().Str("checker", "systemd").Err(err).Msg("Querying systemd via D-Bus failed, falling back to systemctl")
+	states, fallbackErr := s.fallback.Failed(ctx)
+	if fallbackErr != nil {
+		return nil, multierr.Append(err, fallbackErr)
+	}
+
+	return states, nil
+}
@@ -1,26 +1,53 @@
 package checkers
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"regexp"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
 )
 
-const TcpName = "tcp"
-
-var timeout = time.Second * 3
+const (
+	TcpName              = "tcp"
+	defaultTcpTimeout    = 3 * time.Second
+	tcpMaxBannerSize     = 4096
+	tcpBannerReadBufSize = 512
+)
 
+// TcpChecker checks whether a TCP connection to a given server can be established. Optionally, a TLS handshake is
+// performed and the server's certificate is checked for its remaining validity, and a payload is sent and the
+// server's reply matched against a regex, e.g. to verify an SSH or SMTP greeting.
 type TcpChecker struct {
-	host string
-	port string
+	host              string
+	port              string
+	timeout           time.Duration
+	refusedHealthy    bool
+	refusedHealthySet bool
+	useTls            bool
+	tlsServerName     string
+	tlsCaFile         string
+	tlsMinValidity    time.Duration
+	tlsConfig         *tls.Config
+	send              string
+	expect            *regexp.Regexp
+	binding           NetworkBinding
+
+	mutex  sync.Mutex
+	reason string
 }
 
-func NewTcpChecker(host string, port string) (*TcpChecker, error) {
+type TcpOpts func(checker *TcpChecker) error
+
+func NewTcpChecker(host string, port string, opts ...TcpOpts) (*TcpChecker, error) {
 	if len(host) == 0 {
 		return nil, errors.New("empty host provided")
 	}
@@ -29,48 +56,159 @@ func NewTcpChecker(host string, port string) (*TcpChecker, error) {
 		return nil, errors.New("empty port provided")
 	}
 
-	return &TcpChecker{
-		host: host,
-		port: port,
-	}, nil
-}
+	checker := &TcpChecker{
+		host:    host,
+		port:    port,
+		timeout: defaultTcpTimeout,
+	}
 
-func TcpCheckerFromMap(args map[string]any) (*TcpChecker, error) {
-	if len(args) == 0 {
-		return nil, errors.New("can't build tcpchecker, empty args supplied")
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
 	}
 
-	host, ok := args["host"]
-	if !ok {
-		return nil, errors.New("can't build tcpchecker, no 'host' supplied")
+	// a refused connection proves the host is reachable, but not that the probed service is alive
+	if !checker.refusedHealthySet {
+		checker.refusedHealthy = !checker.useTls && len(checker.send) == 0 && checker.expect == nil
 	}
 
-	port, ok := args["port"]
-	if !ok {
-		return nil, errors.New("can't build tcpchecker, no 'port' supplied")
+	if checker.useTls {
+		tlsConfig, err := buildTlsConfig(checker.tlsCaFile, "", "")
+		if err != nil {
+			errs = multierr.Append(errs, err)
+		} else {
+			tlsConfig.ServerName = checker.tlsServerName
+			if len(tlsConfig.ServerName) == 0 {
+				tlsConfig.ServerName = host
+			}
+			checker.tlsConfig = tlsConfig
+		}
 	}
 
-	return NewTcpChecker(fmt.Sprintf("%s", host), fmt.Sprintf("%s", port))
+	if errs != nil {
+		return nil, errs
+	}
+
+	return checker, nil
 }
 
 func (c *TcpChecker) Name() string {
-	return fmt.Sprintf("%s://%s:%s", TcpName, c.host, c.port)
+	scheme := TcpName
+	if c.useTls {
+		scheme += "+tls"
+	}
+	name := fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(c.host, c.port))
+	if !c.binding.IsZero() {
+		name += fmt.Sprintf(" (%s)", c.binding)
+	}
+	return name
 }
 
 func (c *TcpChecker) IsHealthy(ctx context.Context) (bool, error) {
-	conn, err := net.DialTimeout("tcp", net.JoinHostPort(c.host, c.port), timeout)
-	if err == nil && conn != nil {
-		defer conn.Close()
-		log.Debug().Str("checker", "tcp").Msgf("Connecting to %s succeeded", c.Name())
-		return true, nil
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	conn, err := c.binding.DialContext(ctx, "tcp", net.JoinHostPort(c.host, c.port))
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) && c.refusedHealthy {
+			// receiving this error means the remote system replied
+			log.Warn().Str("checker", "tcp").Err(err).Msgf("Review configuration, connection refused to %s", c.Name())
+			c.setReason("")
+			return true, nil
+		}
+
+		c.setReason(err.Error())
+		log.Error().Str("checker", "tcp").Err(err).Msgf("Connectivity checker '%s' encountered errors", c.Name())
+		return false, nil
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if err := c.probe(ctx, conn); err != nil {
+		c.setReason(err.Error())
+		log.Error().Str("checker", "tcp").Err(err).Msgf("Checker '%s' failed", c.Name())
+		return false, nil
+	}
+
+	log.Debug().Str("checker", "tcp").Msgf("Connecting to %s succeeded", c.Name())
+	c.setReason("")
+	return true, nil
+}
+
+// probe performs the optional TLS handshake and banner exchange on an established connection.
+func (c *TcpChecker) probe(ctx context.Context, conn net.Conn) error {
+	if c.useTls {
+		tlsConn := tls.Client(conn, c.tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("tls handshake failed: %w", err)
+		}
+
+		if err := c.checkCertificateValidity(tlsConn.ConnectionState()); err != nil {
+			return err
+		}
+		conn = tlsConn
 	}
 
-	if errors.Is(err, syscall.ECONNREFUSED) {
-		// receiving this error means the remote system replied
-		log.Warn().Str("checker", "tcp").Err(err).Msgf("Review configuration, connection refused to %s", c.Name())
-		return true, nil
+	if len(c.send) > 0 {
+		if _, err := conn.Write([]byte(c.send)); err != nil {
+			return fmt.Errorf("could not send payload: %w", err)
+		}
 	}
 
-	log.Error().Str("checker", "tcp").Err(err).Msgf("Connectivity checker '%s' encountered errors", c.Name())
-	return false, nil
+	if c.expect != nil {
+		return c.expectBanner(conn)
+	}
+
+	return nil
+}
+
+func (c *TcpChecker) checkCertificateValidity(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server did not present a certificate")
+	}
+
+	expires := state.PeerCertificates[0].NotAfter
+	remaining := time.Until(expires)
+	if remaining < c.tlsMinValidity {
+		return fmt.Errorf("certificate expires in %s at %s", remaining.Truncate(time.Second), expires.Format(time.RFC3339))
+	}
+
+	log.Debug().Str("checker", "tcp").Msgf("Certificate of %s expires at %s", c.Name(), expires.Format(time.RFC3339))
+	return nil
+}
+
+// expectBanner reads from the connection until the received data matches the expected regex.
+func (c *TcpChecker) expectBanner(conn net.Conn) error {
+	var received bytes.Buffer
+	buf := make([]byte, tcpBannerReadBufSize)
+	for received.Len() < tcpMaxBannerSize {
+		read, err := conn.Read(buf)
+		received.Write(buf[:read])
+		if c.expect.Match(received.Bytes()) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("expected reply %q not received, got %q: %w", c.expect, received.String(), err)
+		}
+	}
+
+	return fmt.Errorf("expected reply %q not found in first %d bytes", c.expect, tcpMaxBannerSize)
+}
+
+func (c *TcpChecker) setReason(reason string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reason = reason
+}
+
+func (c *TcpChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
 }
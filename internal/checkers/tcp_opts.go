@@ -0,0 +1,155 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+func TcpTimeout(timeout time.Duration) TcpOpts {
+	return func(checker *TcpChecker) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+
+		checker.timeout = timeout
+		return nil
+	}
+}
+
+// TcpRefusedIsHealthy sets whether a refused connection is considered healthy. A refused connection proves the remote
+// system is reachable, but not that the service is alive. By default, refused connections are healthy unless a TLS
+// handshake or banner exchange is configured.
+func TcpRefusedIsHealthy(refusedHealthy bool) TcpOpts {
+	return func(checker *TcpChecker) error {
+		checker.refusedHealthy = refusedHealthy
+		checker.refusedHealthySet = true
+		return nil
+	}
+}
+
+// TcpTls performs a TLS handshake after the connection has been established. The server name defaults to the host.
+func TcpTls(serverName, caFile string) TcpOpts {
+	return func(checker *TcpChecker) error {
+		checker.useTls = true
+		checker.tlsServerName = serverName
+		checker.tlsCaFile = caFile
+		return nil
+	}
+}
+
+// TcpCertMinValidity marks the check as failed if the server's certificate expires within the given duration.
+func TcpCertMinValidity(minValidity time.Duration) TcpOpts {
+	return func(checker *TcpChecker) error {
+		if minValidity < 0 {
+			return errors.New("min validity must not be negative")
+		}
+
+		checker.tlsMinValidity = minValidity
+		return nil
+	}
+}
+
+// TcpSend sends the payload after the connection has been established, e.g. 'HEAD / HTTP/1.0\r\n\r\n'.
+func TcpSend(payload string) TcpOpts {
+	return func(checker *TcpChecker) error {
+		if len(payload) == 0 {
+			return errors.New("empty payload provided")
+		}
+
+		checker.send = payload
+		return nil
+	}
+}
+
+// TcpExpect expects the server to reply with data matching the regex, e.g. '^SSH-2\.0-' or '^220 '.
+func TcpExpect(expect string) TcpOpts {
+	return func(checker *TcpChecker) error {
+		if len(expect) == 0 {
+			return errors.New("empty expect regex provided")
+		}
+
+		regex, err := regexp.Compile(expect)
+		if err != nil {
+			return fmt.Errorf("invalid expect regex: %w", err)
+		}
+
+		checker.expect = regex
+		return nil
+	}
+}
+
+// TcpBinding binds the connection to a source address, an interface or a network namespace.
+func TcpBinding(binding NetworkBinding) TcpOpts {
+	return func(checker *TcpChecker) error {
+		if err := binding.validate(); err != nil {
+			return err
+		}
+
+		checker.binding = binding
+		return nil
+	}
+}
+
+//nolint:cyclop
+func TcpCheckerFromMap(args map[string]any) (*TcpChecker, error) {
+	if len(args) == 0 {
+		return nil, errors.New("can't build tcpchecker, empty args supplied")
+	}
+
+	host, ok := args["host"]
+	if !ok {
+		return nil, errors.New("can't build tcpchecker, no 'host' supplied")
+	}
+
+	port, ok := args["port"]
+	if !ok {
+		return nil, errors.New("can't build tcpchecker, no 'port' supplied")
+	}
+
+	var opts []TcpOpts
+	timeout, ok, err := durationFromArgs(args, "timeout")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, TcpTimeout(timeout))
+	}
+
+	if refusedHealthy, ok := args["refused_is_healthy"].(bool); ok {
+		opts = append(opts, TcpRefusedIsHealthy(refusedHealthy))
+	}
+
+	useTls, _ := args["tls"].(bool)
+	serverName, okServerName := args["tls_server_name"].(string)
+	caFile, okCaFile := args["tls_ca"].(string)
+	if useTls || okServerName || okCaFile {
+		opts = append(opts, TcpTls(serverName, caFile))
+	}
+
+	minValidity, ok, err := durationFromArgs(args, "tls_min_validity")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if !useTls && !okServerName && !okCaFile {
+			return nil, errors.New("can't build tcpchecker, 'tls_min_validity' requires 'tls'")
+		}
+		opts = append(opts, TcpCertMinValidity(minValidity))
+	}
+
+	if send, ok := args["send"].(string); ok {
+		opts = append(opts, TcpSend(send))
+	}
+
+	if expect, ok := args["expect"].(string); ok {
+		opts = append(opts, TcpExpect(expect))
+	}
+
+	if binding, ok := networkBindingFromArgs(args); ok {
+		opts = append(opts, TcpBinding(binding))
+	}
+
+	return NewTcpChecker(fmt.Sprintf("%s", host), fmt.Sprintf("%v", port), opts...)
+}
@@ -1,54 +1,287 @@
 package checkers
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
+	"net"
 	"testing"
+	"time"
 )
 
+// startTcpServer accepts connections on the listener and passes them to handle.
+func startTcpServer(t *testing.T, listener net.Listener, handle func(conn net.Conn)) (string, string) {
+	t.Helper()
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if handle != nil {
+					handle(conn)
+				}
+			}()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	return host, port
+}
+
+func listenTcp(t *testing.T) net.Listener {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	return listener
+}
+
+func sshGreeting(conn net.Conn) {
+	_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+	time.Sleep(100 * time.Millisecond)
+}
+
+func redisPing(conn net.Conn) {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err == nil && line == "PING\r\n" {
+		_, _ = conn.Write([]byte("+PONG\r\n"))
+	}
+}
+
 func TestTcpChecker_IsHealthy(t *testing.T) {
-	type fields struct {
+	closed := listenTcp(t)
+	closedHost, closedPort, _ := net.SplitHostPort(closed.Addr().String())
+	_ = closed.Close()
+
+	silentHost, silentPort := startTcpServer(t, listenTcp(t), func(conn net.Conn) {
+		time.Sleep(time.Second)
+	})
+	sshHost, sshPort := startTcpServer(t, listenTcp(t), sshGreeting)
+	redisHost, redisPort := startTcpServer(t, listenTcp(t), redisPing)
+
+	tests := []struct {
+		name string
 		host string
 		port string
+		opts []TcpOpts
+		want bool
+	}{
+		{
+			name: "connection established",
+			host: silentHost,
+			port: silentPort,
+			want: true,
+		},
+		{
+			name: "connection refused, healthy by default",
+			host: closedHost,
+			port: closedPort,
+			want: true,
+		},
+		{
+			name: "connection refused, unhealthy",
+			host: closedHost,
+			port: closedPort,
+			opts: []TcpOpts{TcpRefusedIsHealthy(false)},
+			want: false,
+		},
+		{
+			name: "connection refused, unhealthy by default when expecting a banner",
+			host: closedHost,
+			port: closedPort,
+			opts: []TcpOpts{TcpExpect(`^SSH-2\.0-`)},
+			want: false,
+		},
+		{
+			name: "connection refused, healthy when expecting a banner if configured",
+			host: closedHost,
+			port: closedPort,
+			opts: []TcpOpts{TcpExpect(`^SSH-2\.0-`), TcpRefusedIsHealthy(true)},
+			want: true,
+		},
+		{
+			name: "ssh greeting",
+			host: sshHost,
+			port: sshPort,
+			opts: []TcpOpts{TcpExpect(`^SSH-2\.0-`)},
+			want: true,
+		},
+		{
+			name: "smtp greeting expected from ssh server",
+			host: sshHost,
+			port: sshPort,
+			opts: []TcpOpts{TcpExpect(`^220 `)},
+			want: false,
+		},
+		{
+			name: "silent server",
+			host: silentHost,
+			port: silentPort,
+			opts: []TcpOpts{TcpExpect(`^SSH-2\.0-`), TcpTimeout(100 * time.Millisecond)},
+			want: false,
+		},
+		{
+			name: "send and expect",
+			host: redisHost,
+			port: redisPort,
+			opts: []TcpOpts{TcpSend("PING\r\n"), TcpExpect(`^\+PONG`)},
+			want: true,
+		}, {
+			name: "bound to source ip",
+			host: sshHost,
+			port: sshPort,
+			opts: []TcpOpts{TcpBinding(NetworkBinding{SourceIp: "127.0.0.1"})},
+			want: true,
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewTcpChecker(tt.host, tt.port, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewTcpChecker() error = %v", err)
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
+
+func TestTcpChecker_IsHealthy_RefusedClearsReason(t *testing.T) {
+	closed := listenTcp(t)
+	host, port, _ := net.SplitHostPort(closed.Addr().String())
+	_ = closed.Close()
+
+	c, err := NewTcpChecker(host, port)
+	if err != nil {
+		t.Fatalf("NewTcpChecker() error = %v", err)
+	}
+	c.setReason("stale")
+
+	if got, _ := c.IsHealthy(context.Background()); !got {
+		t.Errorf("IsHealthy() got = %v, want true", got)
+	}
+	if c.Reason() != "" {
+		t.Errorf("Reason() got = %q, want empty", c.Reason())
+	}
+}
+
+func TestTcpChecker_IsHealthy_Tls(t *testing.T) {
+	certificate, caFile := generateTestCertificate(t, time.Now().Add(24*time.Hour), "smtp.example.com")
+	_, otherCaFile := generateTestCertificate(t, time.Now().Add(24*time.Hour), "smtp.example.com")
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12})
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	host, port := startTcpServer(t, listener, func(conn net.Conn) {
+		_, _ = conn.Write([]byte("220 smtp.example.com ESMTP\r\n"))
+		time.Sleep(100 * time.Millisecond)
+	})
 
 	tests := []struct {
-		name    string
-		fields  fields
-		want    bool
-		wantErr bool
+		name string
+		opts []TcpOpts
+		want bool
 	}{
 		{
-			name: "google dns",
-			fields: fields{
-				host: "8.8.8.8",
-				port: "53",
-			},
-			want:    true,
-			wantErr: false,
+			name: "handshake",
+			opts: []TcpOpts{TcpTls("smtp.example.com", caFile)},
+			want: true,
+		},
+		{
+			name: "handshake and greeting",
+			opts: []TcpOpts{TcpTls("smtp.example.com", caFile), TcpExpect(`^220 `)},
+			want: true,
 		},
 		{
-			name: "google dns",
-			fields: fields{
-				host: "8.8.8.8",
-				port: "54",
-			},
-			want:    false,
-			wantErr: false,
+			name: "untrusted certificate",
+			opts: []TcpOpts{TcpTls("smtp.example.com", otherCaFile)},
+			want: false,
+		},
+		{
+			name: "name mismatch",
+			opts: []TcpOpts{TcpTls("", caFile)},
+			want: false,
+		},
+		{
+			name: "certificate valid long enough",
+			opts: []TcpOpts{TcpTls("smtp.example.com", caFile), TcpCertMinValidity(12 * time.Hour)},
+			want: true,
+		},
+		{
+			name: "certificate expires soon",
+			opts: []TcpOpts{TcpTls("smtp.example.com", caFile), TcpCertMinValidity(48 * time.Hour)},
+			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c := &TcpChecker{
-				host: tt.fields.host,
-				port: tt.fields.port,
+			c, err := NewTcpChecker(host, port, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewTcpChecker() error = %v", err)
 			}
+
 			got, err := c.IsHealthy(context.Background())
-			if (err != nil) != tt.wantErr {
-				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+			if err != nil {
+				t.Errorf("IsHealthy() error = %v", err)
 				return
 			}
 			if got != tt.want {
-				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+				t.Errorf("IsHealthy() got = %v, want %v (%s)", got, tt.want, c.Reason())
+			}
+		})
+	}
+}
+
+func TestTcpCheckerFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr bool
+	}{
+		{
+			name: "numeric port",
+			args: map[string]any{"host": "192.0.2.1", "port": 22, "expect": `^SSH-2\.0-`, "timeout": "5s"},
+		},
+		{
+			name: "tls",
+			args: map[string]any{"host": "192.0.2.1", "port": "465", "tls": true, "tls_min_validity": "72h"},
+		},
+		{
+			name:    "min validity without tls",
+			args:    map[string]any{"host": "192.0.2.1", "port": "465", "tls_min_validity": "72h"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			args:    map[string]any{"host": "192.0.2.1", "port": "22", "expect": "(SSH"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TcpCheckerFromMap(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TcpCheckerFromMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.port != "22" && got.port != "465" {
+				t.Errorf("TcpCheckerFromMap() port = %v", got.port)
 			}
 		})
 	}
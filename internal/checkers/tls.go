@@ -0,0 +1,58 @@
+package checkers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+// buildTlsConfig returns a TLS config that trusts the certificates of the given CA bundle instead of the system's
+// trust store. If a client certificate is given, it is read from disk on each handshake so rotated certificates
+// are picked up without restarting.
+func buildTlsConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	conf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(caFile) > 0 {
+		pool, err := loadCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = pool
+	}
+
+	if len(certFile) > 0 || len(keyFile) > 0 {
+		if len(certFile) == 0 || len(keyFile) == 0 {
+			return nil, errors.New("both client certificate and key need to be supplied")
+		}
+
+		conf.GetClientCertificate = func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				log.Error().Err(err).Msg("user-defined client certificates could not be loaded")
+			}
+			return &certificate, err
+		}
+	}
+
+	return conf, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA file '%s': %w", caFile, err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in CA file '%s'", caFile)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,150 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/conditional-reboot/internal/uptime"
+	"go.uber.org/multierr"
+)
+
+const UptimeCheckerName = "uptime"
+
+// UptimeChecker reports an unhealthy state once the system has been running for longer than the configured
+// duration. An optional jitter adds a per-host offset that is derived from the hostname, so a fleet sharing the same
+// configuration does not reboot at once while each host's deadline stays stable across restarts.
+type UptimeChecker struct {
+	maxUptime time.Duration
+	jitter    time.Duration
+	hostname  string
+	uptime    func() (time.Duration, error)
+
+	mutex  sync.Mutex
+	reason string
+}
+
+type UptimeOpts func(checker *UptimeChecker) error
+
+func NewUptimeChecker(maxUptime time.Duration, opts ...UptimeOpts) (*UptimeChecker, error) {
+	if maxUptime <= 0 {
+		return nil, errors.New("'max_uptime' must be positive")
+	}
+
+	checker := &UptimeChecker{
+		maxUptime: maxUptime,
+		uptime:    uptime.Uptime,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(checker); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if checker.jitter > 0 && len(checker.hostname) == 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("could not determine hostname for jitter: %w", err))
+		}
+		checker.hostname = hostname
+	}
+
+	return checker, errs
+}
+
+func UptimeJitter(jitter time.Duration) UptimeOpts {
+	return func(checker *UptimeChecker) error {
+		if jitter < 0 {
+			return errors.New("jitter must not be negative")
+		}
+
+		checker.jitter = jitter
+		return nil
+	}
+}
+
+// UptimeHostname overrides the hostname the jitter is derived from.
+func UptimeHostname(hostname string) UptimeOpts {
+	return func(checker *UptimeChecker) error {
+		if len(hostname) == 0 {
+			return errors.New("empty hostname provided")
+		}
+
+		checker.hostname = hostname
+		return nil
+	}
+}
+
+func UptimeCheckerFromMap(args map[string]any) (*UptimeChecker, error) {
+	maxUptime, ok, err := durationFromArgs(args, "max_uptime")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("no 'max_uptime' supplied")
+	}
+
+	var opts []UptimeOpts
+	jitter, ok, err := durationFromArgs(args, "jitter")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, UptimeJitter(jitter))
+	}
+
+	if hostname, ok := args["hostname"].(string); ok {
+		opts = append(opts, UptimeHostname(hostname))
+	}
+
+	return NewUptimeChecker(maxUptime, opts...)
+}
+
+func (c *UptimeChecker) Name() string {
+	return fmt.Sprintf("%s (max %s)", UptimeCheckerName, c.MaxUptime())
+}
+
+// MaxUptime returns the configured maximum uptime including the host's jitter.
+func (c *UptimeChecker) MaxUptime() time.Duration {
+	if c.jitter <= 0 {
+		return c.maxUptime
+	}
+
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(c.hostname))
+	offset := time.Duration(hash.Sum64() % uint64(c.jitter.Seconds()+1))
+	return c.maxUptime + offset*time.Second
+}
+
+func (c *UptimeChecker) IsHealthy(_ context.Context) (bool, error) {
+	systemUptime, err := c.uptime()
+	if err != nil {
+		return false, err
+	}
+
+	maxUptime := c.MaxUptime()
+	if systemUptime <= maxUptime {
+		return true, nil
+	}
+
+	reason := fmt.Sprintf("uptime %s exceeds %s", systemUptime.Truncate(time.Second), maxUptime)
+	c.mutex.Lock()
+	c.reason = reason
+	c.mutex.Unlock()
+
+	log.Info().Str("checker", "uptime").Msgf("System %s", reason)
+	return false, nil
+}
+
+func (c *UptimeChecker) Reason() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.reason
+}
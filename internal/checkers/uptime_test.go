@@ -0,0 +1,101 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUptimeChecker_IsHealthy(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name      string
+		maxUptime time.Duration
+		opts      []UptimeOpts
+		uptime    time.Duration
+		uptimeErr error
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "below max uptime",
+			maxUptime: 30 * day,
+			uptime:    29 * day,
+			want:      true,
+		},
+		{
+			name:      "above max uptime",
+			maxUptime: 30 * day,
+			uptime:    31 * day,
+			want:      false,
+		},
+		{
+			name:      "above max uptime, but within jitter",
+			maxUptime: 30 * day,
+			opts:      []UptimeOpts{UptimeJitter(2 * day), UptimeHostname("host-a")},
+			uptime:    30*day + time.Minute,
+			want:      true,
+		},
+		{
+			name:      "above max uptime including jitter",
+			maxUptime: 30 * day,
+			opts:      []UptimeOpts{UptimeJitter(2 * day), UptimeHostname("host-a")},
+			uptime:    32*day + time.Second,
+			want:      false,
+		},
+		{
+			name:      "uptime error",
+			maxUptime: 30 * day,
+			uptimeErr: errors.New("no procfs"),
+			want:      false,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewUptimeChecker(tt.maxUptime, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewUptimeChecker() error = %v", err)
+			}
+			c.uptime = func() (time.Duration, error) {
+				return tt.uptime, tt.uptimeErr
+			}
+
+			got, err := c.IsHealthy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsHealthy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IsHealthy() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUptimeChecker_MaxUptime(t *testing.T) {
+	maxUptime := 30 * 24 * time.Hour
+	jitter := 48 * time.Hour
+
+	offsets := map[time.Duration]bool{}
+	for _, hostname := range []string{"host-a", "host-b", "host-c", "host-d"} {
+		c, err := NewUptimeChecker(maxUptime, UptimeJitter(jitter), UptimeHostname(hostname))
+		if err != nil {
+			t.Fatalf("NewUptimeChecker() error = %v", err)
+		}
+
+		got := c.MaxUptime()
+		if got < maxUptime || got > maxUptime+jitter {
+			t.Errorf("MaxUptime() = %v, not within [%v, %v]", got, maxUptime, maxUptime+jitter)
+		}
+		if again := c.MaxUptime(); again != got {
+			t.Errorf("MaxUptime() not deterministic: %v != %v", again, got)
+		}
+		offsets[got] = true
+	}
+
+	if len(offsets) < 2 {
+		t.Errorf("expected jitter to differ between hosts, got %v", offsets)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import "testing"
+
+func TestReadConfig_ExampleQuorum(t *testing.T) {
+	conf, err := ReadConfig("../../contrib/example_quorum.yaml")
+	if err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	if err := Validate(conf); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if len(conf.Groups) != 1 || len(conf.Groups[0].Agents) != 1 {
+		t.Fatalf("ReadConfig() expected a single group with a single agent, got %+v", conf.Groups)
+	}
+
+	agent := conf.Groups[0].Agents[0]
+	if agent.CheckerName != "quorum" {
+		t.Errorf("CheckerName = %q, want %q", agent.CheckerName, "quorum")
+	}
+	if agent.StreakUntilOk != 1 {
+		t.Errorf("StreakUntilOk = %d, want 1", agent.StreakUntilOk)
+	}
+	if agent.StreakUntilReboot != 3 {
+		t.Errorf("StreakUntilReboot = %d, want 3", agent.StreakUntilReboot)
+	}
+}
@@ -39,6 +39,10 @@ func (a *agent) CheckerNiceName() string {
 	return ""
 }
 
+func (a *agent) CheckerReason() string {
+	return ""
+}
+
 type args struct {
 	agents []state.Agent
 }